@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var airflowVersionPrefix = regexp.MustCompile(`^[0-9]+(\.[0-9]+)*`)
+
+// parseAirflowVersion accepts the versions reported by managed services and
+// development builds, such as 2.5.1+composer or 3.0.0.dev0, by falling back to
+// their numeric prefix.
+func parseAirflowVersion(v string) (*version.Version, error) {
+	if parsed, err := version.NewVersion(v); err == nil {
+		return parsed, nil
+	}
+
+	prefix := airflowVersionPrefix.FindString(v)
+	if prefix == "" {
+		return nil, fmt.Errorf("not a version")
+	}
+
+	return version.NewVersion(prefix)
+}
+
+// requireAirflowVersion fails plans that configure attributes the Airflow
+// server is too old to know, which it would otherwise reject at apply time
+// with an opaque error. minVersions maps attributes to the Airflow version
+// that introduced them.
+func requireAirflowVersion(minVersions map[string]string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+		pcfg, ok := m.(ProviderConfig)
+		if !ok || pcfg.AirflowVersion == nil {
+			return nil
+		}
+
+		attributes := make([]string, 0, len(minVersions))
+		for attribute := range minVersions {
+			attributes = append(attributes, attribute)
+		}
+		sort.Strings(attributes)
+
+		var errs []error
+		for _, attribute := range attributes {
+			if _, ok := d.GetOk(attribute); !ok {
+				continue
+			}
+
+			minVersion := version.Must(version.NewVersion(minVersions[attribute]))
+			if pcfg.AirflowVersion.Core().LessThan(minVersion) {
+				errs = append(errs, fmt.Errorf("`%s` requires Airflow %s or later, the server runs Airflow %s", attribute, minVersion, pcfg.AirflowVersion))
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestParseAirflowVersion(t *testing.T) {
+	cases := map[string]string{
+		"2.5.1":          "2.5.1",
+		"2.5.1+composer": "2.5.1",
+		"2.7.0rc1":       "2.7.0",
+		"3.0.0.dev0":     "3.0.0",
+	}
+
+	for v, expected := range cases {
+		parsed, err := parseAirflowVersion(v)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", v, err)
+			continue
+		}
+		if got := parsed.Core().String(); got != expected {
+			t.Errorf("expected %s for %s, got %s", expected, v, got)
+		}
+	}
+
+	if _, err := parseAirflowVersion("unknown"); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestRequireAirflowVersion(t *testing.T) {
+	cases := []struct {
+		airflowVersion string
+		config         map[string]interface{}
+		err            string
+	}{
+		{
+			airflowVersion: "2.2.5",
+			config:         map[string]interface{}{"connection_id": "example", "conn_type": "http", "description": "hello"},
+			err:            "`description` requires Airflow 2.3.0 or later, the server runs Airflow 2.2.5",
+		},
+		{
+			airflowVersion: "2.2.5",
+			config:         map[string]interface{}{"connection_id": "example", "conn_type": "http"},
+		},
+		{
+			airflowVersion: "2.3.0rc1",
+			config:         map[string]interface{}{"connection_id": "example", "conn_type": "http", "description": "hello"},
+		},
+		{
+			airflowVersion: "3.0.1",
+			config:         map[string]interface{}{"connection_id": "example", "conn_type": "http", "description": "hello"},
+		},
+	}
+
+	for _, tc := range cases {
+		pcfg := ProviderConfig{AirflowVersion: version.Must(version.NewVersion(tc.airflowVersion))}
+
+		_, err := resourceConnection().SimpleDiff(context.Background(), nil, terraform.NewResourceConfigRaw(tc.config), pcfg)
+		if tc.err == "" {
+			if err != nil {
+				t.Errorf("unexpected error for %s with %v: %s", tc.airflowVersion, tc.config, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("expected error %q for %s, got %v", tc.err, tc.airflowVersion, err)
+		}
+	}
+}
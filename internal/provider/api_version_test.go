@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestDetectApiVersion(t *testing.T) {
+	cases := []struct {
+		served   string
+		expected string
+	}{
+		{served: "/api/v1/version", expected: apiVersionV1},
+		{served: "/api/v2/version", expected: apiVersionV2},
+	}
+
+	for _, tc := range cases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != tc.served {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"version": "2.5.1"}`)
+		}))
+
+		u, _ := url.Parse(server.URL)
+		apiVersion, serverVersion, err := detectApiVersion(context.Background(), u, "", server.Client())
+		server.Close()
+
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %s", tc.served, err)
+		}
+		if apiVersion != tc.expected {
+			t.Errorf("expected %s for %s, got %s", tc.expected, tc.served, apiVersion)
+		}
+		if serverVersion != "2.5.1" {
+			t.Errorf("expected server version 2.5.1 for %s, got %s", tc.served, serverVersion)
+		}
+	}
+}
+
+func TestDetectApiVersion_notFound(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	if _, _, err := detectApiVersion(context.Background(), u, "", server.Client()); err == nil {
+		t.Fatal("expected an error when no REST API is served")
+	}
+}
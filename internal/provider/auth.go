@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// cachingTokenSource hands out the token of the wrapped source until it
+// expires or is rejected by Airflow.
+type cachingTokenSource struct {
+	mu     sync.Mutex
+	source oauth2.TokenSource
+	token  *oauth2.Token
+}
+
+func newCachingTokenSource(source oauth2.TokenSource) *cachingTokenSource {
+	return &cachingTokenSource{source: source}
+}
+
+// contextTokenSource is implemented by token sources that make requests of
+// their own, so that they can be cancelled along with the API request.
+type contextTokenSource interface {
+	TokenContext(ctx context.Context) (*oauth2.Token, error)
+}
+
+func (s *cachingTokenSource) Token() (*oauth2.Token, error) {
+	return s.TokenContext(context.Background())
+}
+
+func (s *cachingTokenSource) TokenContext(ctx context.Context) (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.Valid() {
+		return s.token, nil
+	}
+
+	var token *oauth2.Token
+	var err error
+	if source, ok := s.source.(contextTokenSource); ok {
+		token, err = source.TokenContext(ctx)
+	} else {
+		token, err = s.source.Token()
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.token = token
+
+	return token, nil
+}
+
+func (s *cachingTokenSource) expire(token *oauth2.Token) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = nil
+	}
+}
+
+// tokenTransport authenticates requests with a token, sent as bearer token
+// unless authorize says otherwise, and retries a request once with a fresh
+// token when Airflow answers with 401.
+type tokenTransport struct {
+	source    *cachingTokenSource
+	base      http.RoundTripper
+	authorize func(*http.Request, *oauth2.Token)
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source.TokenContext(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(t.authorizedRequest(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	t.source.expire(token)
+	if token, err = t.source.TokenContext(req.Context()); err != nil {
+		return resp, nil
+	}
+
+	retry := t.authorizedRequest(req, token)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	resp.Body.Close()
+
+	return t.base.RoundTrip(retry)
+}
+
+func (t *tokenTransport) authorizedRequest(req *http.Request, token *oauth2.Token) *http.Request {
+	r := req.Clone(req.Context())
+	if t.authorize != nil {
+		t.authorize(r, token)
+	} else {
+		token.SetAuthHeader(r)
+	}
+
+	return r
+}
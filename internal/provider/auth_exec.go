@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// tokenFileRefresh is how long a token read from a file is used before the
+// file is read again, so that rotated tokens are picked up.
+const tokenFileRefresh = time.Minute
+
+// execTokenSource runs a credential helper that prints a token and its
+// expiry as JSON, such as {"token": "...", "expiry": "2024-01-01T00:00:00Z"}.
+type execTokenSource struct {
+	command string
+	args    []string
+	env     []string
+}
+
+func newExecTokenSource(tfList []interface{}) *execTokenSource {
+	tfMap := tfList[0].(map[string]interface{})
+
+	source := &execTokenSource{
+		command: tfMap["command"].(string),
+	}
+
+	if v, ok := tfMap["args"].([]interface{}); ok {
+		for _, arg := range v {
+			source.args = append(source.args, arg.(string))
+		}
+	}
+
+	if v, ok := tfMap["env"].(map[string]interface{}); ok {
+		for k, v := range v {
+			source.env = append(source.env, fmt.Sprintf("%s=%s", k, v.(string)))
+		}
+	}
+
+	return source
+}
+
+func (s *execTokenSource) Token() (*oauth2.Token, error) {
+	return s.TokenContext(context.Background())
+}
+
+func (s *execTokenSource) TokenContext(ctx context.Context) (*oauth2.Token, error) {
+	cmd := exec.CommandContext(ctx, s.command, s.args...)
+	cmd.Env = append(os.Environ(), s.env...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run credential command `%s`: %s %s", s.command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var credential struct {
+		Token  string    `json:"token"`
+		Expiry time.Time `json:"expiry"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &credential); err != nil {
+		return nil, fmt.Errorf("failed to decode output of credential command `%s`: %s", s.command, err)
+	}
+	if credential.Token == "" {
+		return nil, fmt.Errorf("no token in output of credential command `%s`", s.command)
+	}
+
+	// Without an expiry the token is used until Airflow rejects it.
+	return &oauth2.Token{
+		AccessToken: credential.Token,
+		TokenType:   "Bearer",
+		Expiry:      credential.Expiry,
+	}, nil
+}
+
+// fileTokenSource reads a token from a file that is rotated by an external
+// agent.
+type fileTokenSource struct {
+	path string
+}
+
+func (s *fileTokenSource) Token() (*oauth2.Token, error) {
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read oauth2_token_file: %s", err)
+	}
+
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return nil, fmt.Errorf("oauth2_token_file `%s` is empty", s.path)
+	}
+
+	expiry := time.Now().Add(tokenFileRefresh)
+	if exp := jwtExpiry(token); !exp.IsZero() && exp.Before(expiry) {
+		expiry = exp
+	}
+
+	return &oauth2.Token{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		Expiry:      expiry,
+	}, nil
+}
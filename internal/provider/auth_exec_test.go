@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExecTokenSource(t *testing.T) {
+	source := newExecTokenSource([]interface{}{
+		map[string]interface{}{
+			"command": "sh",
+			"args":    []interface{}{"-c", `printf '{"token": "%s", "expiry": "2030-01-01T00:00:00Z"}' "$AIRFLOW_TOKEN"`},
+			"env":     map[string]interface{}{"AIRFLOW_TOKEN": "exec-token"},
+		},
+	})
+
+	token, err := source.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.AccessToken != "exec-token" {
+		t.Errorf("unexpected access token %q", token.AccessToken)
+	}
+	if !token.Expiry.Equal(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected expiry %s", token.Expiry)
+	}
+}
+
+func TestExecTokenSourceFailure(t *testing.T) {
+	source := newExecTokenSource([]interface{}{
+		map[string]interface{}{
+			"command": "sh",
+			"args":    []interface{}{"-c", "echo 'not logged in' >&2; exit 1"},
+		},
+	})
+
+	if _, err := source.Token(); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestFileTokenSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("first-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	source := newCachingTokenSource(&fileTokenSource{path: path})
+
+	token, err := source.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.AccessToken != "first-token" {
+		t.Errorf("unexpected access token %q", token.AccessToken)
+	}
+
+	if err := os.WriteFile(path, []byte("second-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	source.expire(token)
+
+	if token, err = source.Token(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.AccessToken != "second-token" {
+		t.Errorf("expected the rotated token to be read, got %q", token.AccessToken)
+	}
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/compute/metadata"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"golang.org/x/oauth2/jws"
+)
+
+const googleTokenEndpoint = "https://oauth2.googleapis.com/token"
+
+type googleCredentials struct {
+	Type         string `json:"type"`
+	ClientEmail  string `json:"client_email"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// googleIdTokenSource mints Google-signed OIDC ID tokens for the webserver or
+// IAP client ID given as audience, either by signing a JWT assertion with a
+// service account key or by asking the metadata server when running on Google
+// Cloud without a key.
+type googleIdTokenSource struct {
+	client        *http.Client
+	audience      string
+	tokenEndpoint string
+	email         string
+	keyID         string
+	key           *rsa.PrivateKey
+}
+
+func newGoogleIdTokenSource(ctx context.Context, tfList []interface{}, client *http.Client) (*googleIdTokenSource, error) {
+	tfMap := tfList[0].(map[string]interface{})
+
+	source := &googleIdTokenSource{
+		client:   client,
+		audience: tfMap["target_audience"].(string),
+	}
+
+	var credsJSON []byte
+	if v, ok := tfMap["credentials_file"].(string); ok && v != "" {
+		b, err := os.ReadFile(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read credentials_file: %s", err)
+		}
+		credsJSON = b
+	} else if v, ok := tfMap["credentials_json"].(string); ok && v != "" {
+		credsJSON = []byte(v)
+	} else {
+		creds, err := google.FindDefaultCredentials(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find application default credentials: %s", err)
+		}
+		credsJSON = creds.JSON
+	}
+
+	// Application default credentials on Google Cloud come without a key,
+	// the metadata server mints ID tokens for the attached service account.
+	if len(credsJSON) == 0 {
+		return source, nil
+	}
+
+	var creds googleCredentials
+	if err := json.Unmarshal(credsJSON, &creds); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials: %s", err)
+	}
+	if creds.Type != "service_account" {
+		return nil, fmt.Errorf("credentials of type `%s` cannot mint ID tokens, use service account credentials", creds.Type)
+	}
+
+	key, err := parseRSAPrivateKey(creds.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse service account private key: %s", err)
+	}
+
+	source.email = creds.ClientEmail
+	source.keyID = creds.PrivateKeyID
+	source.key = key
+	source.tokenEndpoint = creds.TokenURI
+	if source.tokenEndpoint == "" {
+		source.tokenEndpoint = googleTokenEndpoint
+	}
+	if v, ok := tfMap["token_endpoint"].(string); ok && v != "" {
+		source.tokenEndpoint = v
+	}
+
+	return source, nil
+}
+
+func (s *googleIdTokenSource) Token() (*oauth2.Token, error) {
+	return s.TokenContext(context.Background())
+}
+
+func (s *googleIdTokenSource) TokenContext(ctx context.Context) (*oauth2.Token, error) {
+	var idToken string
+	var err error
+	if s.key == nil {
+		idToken, err = metadata.GetWithContext(ctx, fmt.Sprintf("instance/service-accounts/default/identity?audience=%s&format=full", url.QueryEscape(s.audience)))
+	} else {
+		idToken, err = s.exchangeAssertion(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to mint Google ID token for audience `%s`: %s", s.audience, err)
+	}
+
+	return &oauth2.Token{
+		AccessToken: idToken,
+		TokenType:   "Bearer",
+		Expiry:      jwtExpiry(idToken),
+	}, nil
+}
+
+func (s *googleIdTokenSource) exchangeAssertion(ctx context.Context) (string, error) {
+	now := time.Now()
+	assertion, err := jws.Encode(&jws.Header{
+		Algorithm: "RS256",
+		Typ:       "JWT",
+		KeyID:     s.keyID,
+	}, &jws.ClaimSet{
+		Iss: s.email,
+		Aud: s.tokenEndpoint,
+		Iat: now.Unix(),
+		Exp: now.Add(time.Hour).Unix(),
+		PrivateClaims: map[string]interface{}{
+			"target_audience": s.audience,
+		},
+	}, s.key)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("%s returned %s %s", s.tokenEndpoint, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var tokenResp struct {
+		IdToken string `json:"id_token"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", err
+	}
+	if tokenResp.IdToken == "" {
+		return "", fmt.Errorf("no id_token in response from %s", s.tokenEndpoint)
+	}
+
+	return tokenResp.IdToken, nil
+}
+
+func parseRSAPrivateKey(v string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(v))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM-encoded key found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("key is not an RSA private key")
+	}
+
+	return key, nil
+}
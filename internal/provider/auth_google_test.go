@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testGoogleIdToken(audience string, exp time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"aud":%q,"exp":%d}`, audience, exp.Unix())))
+	return "header." + payload + ".signature"
+}
+
+func TestGoogleIdTokenSource_serviceAccount(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		parts := strings.Split(r.FormValue("assertion"), ".")
+		claims, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		var claimSet map[string]interface{}
+		json.Unmarshal(claims, &claimSet) //nolint:errcheck
+		if claimSet["iss"] != "terraform@example.iam.gserviceaccount.com" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"id_token": %q}`, testGoogleIdToken(claimSet["target_audience"].(string), exp))
+	}))
+	defer server.Close()
+
+	creds, _ := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"client_email":   "terraform@example.iam.gserviceaccount.com",
+		"private_key_id": "key-id",
+		"private_key":    string(keyPEM),
+		"token_uri":      "https://oauth2.googleapis.com/token",
+	})
+
+	source, err := newGoogleIdTokenSource(context.Background(), []interface{}{
+		map[string]interface{}{
+			"target_audience":  "example.apps.googleusercontent.com",
+			"credentials_json": string(creds),
+			"token_endpoint":   server.URL,
+		},
+	}, server.Client())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	token, err := source.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.AccessToken != testGoogleIdToken("example.apps.googleusercontent.com", exp) {
+		t.Errorf("unexpected ID token %q", token.AccessToken)
+	}
+	if !token.Expiry.Equal(exp) {
+		t.Errorf("expected expiry %s, got %s", exp, token.Expiry)
+	}
+}
+
+func TestGoogleIdTokenSource_metadataServer(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Metadata-Flavor", "Google")
+		if r.URL.Path != "/computeMetadata/v1/instance/service-accounts/default/identity" || r.Header.Get("Metadata-Flavor") != "Google" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, testGoogleIdToken(r.URL.Query().Get("audience"), exp))
+	}))
+	defer server.Close()
+
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+	t.Setenv("CLOUDSDK_CONFIG", t.TempDir())
+	t.Setenv("GCE_METADATA_HOST", strings.TrimPrefix(server.URL, "http://"))
+
+	source, err := newGoogleIdTokenSource(context.Background(), []interface{}{
+		map[string]interface{}{
+			"target_audience": "https://airflow.example.com",
+		},
+	}, server.Client())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	token, err := source.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.AccessToken != testGoogleIdToken("https://airflow.example.com", exp) {
+		t.Errorf("unexpected ID token %q", token.AccessToken)
+	}
+}
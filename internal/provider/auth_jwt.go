@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// jwtTokenSource exchanges a username and password for a JWT at the
+// /auth/token endpoint of the Airflow 3 auth managers.
+type jwtTokenSource struct {
+	client   *http.Client
+	tokenURL string
+	username string
+	password string
+}
+
+func (s *jwtTokenSource) Token() (*oauth2.Token, error) {
+	return s.TokenContext(context.Background())
+}
+
+func (s *jwtTokenSource) TokenContext(ctx context.Context) (*oauth2.Token, error) {
+	body, err := json.Marshal(map[string]string{
+		"username": s.username,
+		"password": s.password,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request token from %s: %s", s.tokenURL, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to request token from %s: %s %s", s.tokenURL, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(respBody, &tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to decode token from %s: %s", s.tokenURL, err)
+	}
+	if tokenResp.AccessToken == "" {
+		return nil, fmt.Errorf("no access_token in response from %s", s.tokenURL)
+	}
+
+	return &oauth2.Token{
+		AccessToken: tokenResp.AccessToken,
+		TokenType:   "Bearer",
+		Expiry:      jwtExpiry(tokenResp.AccessToken),
+	}, nil
+}
+
+// jwtExpiry reads the exp claim of a JWT without verifying it. A zero time is
+// returned when the token carries no expiry, in which case it is only renewed
+// once Airflow rejects it.
+func jwtExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(claims.Exp, 0)
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestJwtExpiry(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":"airflow","exp":%d}`, exp.Unix())))
+
+	if got := jwtExpiry("header." + payload + ".signature"); !got.Equal(exp) {
+		t.Errorf("expected expiry %s, got %s", exp, got)
+	}
+	if got := jwtExpiry("not-a-jwt"); !got.IsZero() {
+		t.Errorf("expected zero expiry for an opaque token, got %s", got)
+	}
+}
+
+func TestTokenTransport_jwtRefreshOnUnauthorized(t *testing.T) {
+	var issued int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/token":
+			var creds map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&creds); err != nil || creds["username"] != "airflow" || creds["password"] != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"access_token": "token-%d"}`, atomic.AddInt32(&issued, 1))
+		default:
+			// Only the second token is accepted, the first one has "expired".
+			if r.Header.Get("Authorization") != "Bearer token-2" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &tokenTransport{
+			source: newCachingTokenSource(&jwtTokenSource{
+				client:   server.Client(),
+				tokenURL: server.URL + "/auth/token",
+				username: "airflow",
+				password: "secret",
+			}),
+			base: http.DefaultTransport,
+		},
+	}
+
+	resp, err := client.Post(server.URL+"/api/v2/variables", "application/json", strings.NewReader(`{"key":"foo"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected request to succeed after token refresh, got %s", resp.Status)
+	}
+	if issued != 2 {
+		t.Errorf("expected 2 tokens to be issued, got %d", issued)
+	}
+}
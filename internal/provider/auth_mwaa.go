@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/mwaa"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"golang.org/x/oauth2"
+)
+
+const mwaaSessionCookie = "session"
+
+// mwaaSessionSource obtains a web login token from the MWAA control plane and
+// exchanges it for a webserver session, which authenticates REST API calls.
+type mwaaSessionSource struct {
+	mwaaClient  *mwaa.Client
+	httpClient  *http.Client
+	environment string
+	loginURL    string
+}
+
+func newMwaaSessionSource(ctx context.Context, tfList []interface{}, loginURL string, httpClient *http.Client) (*mwaaSessionSource, error) {
+	tfMap := tfList[0].(map[string]interface{})
+
+	var opts []func(*config.LoadOptions) error
+	if v, ok := tfMap["region"].(string); ok && v != "" {
+		opts = append(opts, config.WithRegion(v))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %s", err)
+	}
+
+	mwaaClient := mwaa.NewFromConfig(cfg, func(o *mwaa.Options) {
+		if v, ok := tfMap["endpoint"].(string); ok && v != "" {
+			o.BaseEndpoint = aws.String(v)
+			// A custom endpoint is used as is, without the "env." host prefix
+			// of the web login token operation.
+			o.APIOptions = append(o.APIOptions, func(stack *middleware.Stack) error {
+				return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("DisableEndpointHostPrefix", func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+					return next.HandleInitialize(smithyhttp.DisableEndpointHostPrefix(ctx, true), in)
+				}), middleware.Before)
+			})
+		}
+	})
+
+	// The login endpoint answers with a redirect to the UI, the session
+	// cookie is all that is needed from it.
+	loginClient := *httpClient
+	loginClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return &mwaaSessionSource{
+		mwaaClient:  mwaaClient,
+		httpClient:  &loginClient,
+		environment: tfMap["environment_name"].(string),
+		loginURL:    loginURL,
+	}, nil
+}
+
+func (s *mwaaSessionSource) Token() (*oauth2.Token, error) {
+	return s.TokenContext(context.Background())
+}
+
+func (s *mwaaSessionSource) TokenContext(ctx context.Context) (*oauth2.Token, error) {
+	out, err := s.mwaaClient.CreateWebLoginToken(ctx, &mwaa.CreateWebLoginTokenInput{
+		Name: aws.String(s.environment),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create web login token for MWAA environment `%s`: %s", s.environment, err)
+	}
+
+	form := url.Values{"token": {aws.ToString(out.WebToken)}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.loginURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to log in to MWAA environment `%s`: %s", s.environment, err)
+	}
+	defer resp.Body.Close()
+
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == mwaaSessionCookie && cookie.Value != "" {
+			return &oauth2.Token{
+				AccessToken: cookie.Value,
+				Expiry:      cookie.Expires,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to log in to MWAA environment `%s`: no session cookie in %s response", s.environment, resp.Status)
+}
+
+func setMwaaSessionCookie(r *http.Request, token *oauth2.Token) {
+	r.AddCookie(&http.Cookie{Name: mwaaSessionCookie, Value: token.AccessToken})
+}
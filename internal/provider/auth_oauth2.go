@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// clientCredentialsTokenSource fetches access tokens from an identity
+// provider with the OAuth2 client credentials grant.
+type clientCredentialsTokenSource struct {
+	config *clientcredentials.Config
+	client *http.Client
+}
+
+func newClientCredentialsTokenSource(tfList []interface{}, client *http.Client) *clientCredentialsTokenSource {
+	tfMap := tfList[0].(map[string]interface{})
+
+	config := &clientcredentials.Config{
+		ClientID:     tfMap["client_id"].(string),
+		ClientSecret: tfMap["client_secret"].(string),
+		TokenURL:     tfMap["token_url"].(string),
+	}
+
+	for _, scope := range tfMap["scopes"].([]interface{}) {
+		config.Scopes = append(config.Scopes, scope.(string))
+	}
+
+	if v := tfMap["endpoint_params"].(map[string]interface{}); len(v) > 0 {
+		config.EndpointParams = make(map[string][]string, len(v))
+		for k, v := range v {
+			config.EndpointParams.Set(k, v.(string))
+		}
+	}
+
+	return &clientCredentialsTokenSource{
+		config: config,
+		client: client,
+	}
+}
+
+func (s *clientCredentialsTokenSource) Token() (*oauth2.Token, error) {
+	return s.TokenContext(context.Background())
+}
+
+func (s *clientCredentialsTokenSource) TokenContext(ctx context.Context) (*oauth2.Token, error) {
+	return s.config.Token(context.WithValue(ctx, oauth2.HTTPClient, s.client))
+}
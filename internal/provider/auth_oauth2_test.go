@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientCredentialsTokenSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientId, clientSecret, _ := r.BasicAuth()
+		if clientId != "terraform" || clientSecret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.FormValue("grant_type") != "client_credentials" || r.FormValue("scope") != "airflow:read airflow:write" || r.FormValue("audience") != "airflow" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token": "access-token", "token_type": "Bearer", "expires_in": 300}`)
+	}))
+	defer server.Close()
+
+	source := newClientCredentialsTokenSource([]interface{}{
+		map[string]interface{}{
+			"token_url":       server.URL,
+			"client_id":       "terraform",
+			"client_secret":   "secret",
+			"scopes":          []interface{}{"airflow:read", "airflow:write"},
+			"endpoint_params": map[string]interface{}{"audience": "airflow"},
+		},
+	}, server.Client())
+
+	token, err := source.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.AccessToken != "access-token" {
+		t.Errorf("unexpected access token %q", token.AccessToken)
+	}
+	if time.Until(token.Expiry) > 5*time.Minute || time.Until(token.Expiry) < 4*time.Minute {
+		t.Errorf("expected token to expire in 5 minutes, got %s", token.Expiry)
+	}
+}
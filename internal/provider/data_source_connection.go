@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSourceWithConfigure = &connectionDataSource{}
+
+type connectionDataSource struct {
+	providerData any
+}
+
+type connectionDataSourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	ConnectionId types.String `tfsdk:"connection_id"`
+	ConnType     types.String `tfsdk:"conn_type"`
+	Description  types.String `tfsdk:"description"`
+	Host         types.String `tfsdk:"host"`
+	Login        types.String `tfsdk:"login"`
+	Schema       types.String `tfsdk:"schema"`
+	Port         types.Int64  `tfsdk:"port"`
+	Password     types.String `tfsdk:"password"`
+	Extra        types.String `tfsdk:"extra"`
+	ExtraMap     types.Map    `tfsdk:"extra_map"`
+}
+
+// connectionExtraMap decodes the extra of a connection when it is a JSON
+// object. Values other than strings are JSON encoded.
+func connectionExtraMap(extra string) (map[string]string, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(extra), &fields); err != nil || fields == nil {
+		return nil, false
+	}
+
+	m := make(map[string]string, len(fields))
+	for k, raw := range fields {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			m[k] = s
+		} else {
+			m[k] = string(raw)
+		}
+	}
+
+	return m, true
+}
+
+func newConnectionDataSource() datasource.DataSource {
+	return &connectionDataSource{}
+}
+
+func (d *connectionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connection"
+}
+
+func (d *connectionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads a connection without managing it",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"connection_id": schema.StringAttribute{
+				Required: true,
+			},
+			"conn_type": schema.StringAttribute{
+				Computed: true,
+			},
+			"description": schema.StringAttribute{
+				Computed: true,
+			},
+			"host": schema.StringAttribute{
+				Computed: true,
+			},
+			"login": schema.StringAttribute{
+				Computed: true,
+			},
+			"schema": schema.StringAttribute{
+				Computed: true,
+			},
+			"port": schema.Int64Attribute{
+				Computed: true,
+			},
+			"password": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The password, when Airflow is configured to return it",
+			},
+			"extra": schema.StringAttribute{
+				Computed: true,
+			},
+			"extra_map": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The extra decoded from JSON, with values other than strings JSON encoded",
+			},
+		},
+	}
+}
+
+func (d *connectionDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	d.providerData = req.ProviderData
+}
+
+func (d *connectionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config connectionDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pcfg, diags := frameworkProviderConfig(d.providerData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connId := config.ConnectionId.ValueString()
+
+	ctx, end := startOperation(ctx, pcfg, "airflow_connection", "read", config.ConnectionId.ValueString)
+	defer func() { end(frameworkErrorSummary(resp.Diagnostics)) }()
+
+	connection, _, err := pcfg.ApiClient.ConnectionApi.GetConnection(pcfg.AuthContext(ctx), connId).Execute()
+	if err != nil {
+		resp.Diagnostics.Append(frameworkDiags(apiErrorf(nil, err, "failed to get connection `%s` from Airflow", connId))...)
+		return
+	}
+
+	state := connectionDataSourceModel{
+		Id:           types.StringValue(connection.GetConnectionId()),
+		ConnectionId: types.StringValue(connection.GetConnectionId()),
+		ConnType:     types.StringValue(connection.GetConnType()),
+		Description:  types.StringValue(pcfg.withoutManagedByMarker(connection.GetDescription())),
+		Host:         types.StringValue(connection.GetHost()),
+		Login:        types.StringValue(connection.GetLogin()),
+		Schema:       types.StringValue(connection.GetSchema()),
+		Port:         types.Int64Value(int64(connection.GetPort())),
+		Password:     types.StringNull(),
+		Extra:        types.StringValue(connection.GetExtra()),
+		ExtraMap:     types.MapNull(types.StringType),
+	}
+
+	if v, ok := connection.GetPasswordOk(); ok {
+		state.Password = types.StringPointerValue(v)
+	}
+
+	if extra, ok := connectionExtraMap(connection.GetExtra()); ok {
+		state.ExtraMap, diags = types.MapValueFrom(ctx, types.StringType, extra)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAirflowConnectionDataSource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	dataSourceName := "data.airflow_connection.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckAirflowConnectionCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAirflowConnectionDataSourceConfigBasic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "connection_id", rName),
+					resource.TestCheckResourceAttr(dataSourceName, "conn_type", "http"),
+					resource.TestCheckResourceAttr(dataSourceName, "host", "example.com"),
+					resource.TestCheckResourceAttr(dataSourceName, "port", "443"),
+					resource.TestCheckResourceAttr(dataSourceName, "extra_map.timeout", "30"),
+					resource.TestCheckResourceAttr(dataSourceName, "extra_map.verify", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestConnectionExtraMap(t *testing.T) {
+	extra, ok := connectionExtraMap(`{"region": "eu-west-1", "retries": 3, "tags": ["a"]}`)
+	if !ok {
+		t.Fatal("expected the extra to be decoded")
+	}
+	if extra["region"] != "eu-west-1" || extra["retries"] != "3" || extra["tags"] != `["a"]` {
+		t.Errorf("unexpected extra %v", extra)
+	}
+
+	for _, v := range []string{"", "not json", `["a"]`, "null"} {
+		if _, ok := connectionExtraMap(v); ok {
+			t.Errorf("expected %q not to be decoded", v)
+		}
+	}
+}
+
+func testAccAirflowConnectionDataSourceConfigBasic(rName string) string {
+	return fmt.Sprintf(`
+resource "airflow_connection" "test" {
+  connection_id = %[1]q
+  conn_type     = "http"
+  host          = "example.com"
+  port          = 443
+  extra         = jsonencode({ timeout = 30, verify = false })
+}
+
+data "airflow_connection" "test" {
+  connection_id = airflow_connection.test.connection_id
+}
+`, rName)
+}
@@ -0,0 +1,345 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSourceWithConfigure = &dagDataSource{}
+
+type dagDataSource struct {
+	providerData any
+}
+
+type dagDataSourceModel struct {
+	Id                   types.String `tfsdk:"id"`
+	DagId                types.String `tfsdk:"dag_id"`
+	Description          types.String `tfsdk:"description"`
+	Fileloc              types.String `tfsdk:"fileloc"`
+	IsActive             types.Bool   `tfsdk:"is_active"`
+	IsPaused             types.Bool   `tfsdk:"is_paused"`
+	Owners               types.List   `tfsdk:"owners"`
+	Tags                 types.List   `tfsdk:"tags"`
+	ScheduleInterval     types.String `tfsdk:"schedule_interval"`
+	TimetableSummary     types.String `tfsdk:"timetable_summary"`
+	TimetableDescription types.String `tfsdk:"timetable_description"`
+	Params               types.Map    `tfsdk:"params"`
+	MaxActiveRuns        types.Int64  `tfsdk:"max_active_runs"`
+	MaxActiveTasks       types.Int64  `tfsdk:"max_active_tasks"`
+	Catchup              types.Bool   `tfsdk:"catchup"`
+	NextDagrun           types.String `tfsdk:"next_dagrun"`
+	LastParsedTime       types.String `tfsdk:"last_parsed_time"`
+	DatasetExpression    types.String `tfsdk:"dataset_expression"`
+	DatasetTriggers      types.List   `tfsdk:"dataset_triggers"`
+}
+
+// dagDetails is decoded from the response body rather than with the DAGDetail
+// model of the generated client, which fails on the schedules and params of
+// recent Airflow versions. The fields renamed by Airflow 3 are decoded under
+// both names.
+type dagDetails struct {
+	DagId                 string                     `json:"dag_id"`
+	Description           *string                    `json:"description"`
+	Fileloc               string                     `json:"fileloc"`
+	IsActive              *bool                      `json:"is_active"`
+	IsStale               *bool                      `json:"is_stale"`
+	IsPaused              bool                       `json:"is_paused"`
+	Owners                []string                   `json:"owners"`
+	Tags                  []dagTag                   `json:"tags"`
+	ScheduleInterval      *dagScheduleInterval       `json:"schedule_interval"`
+	TimetableSummary      *string                    `json:"timetable_summary"`
+	TimetableDescription  *string                    `json:"timetable_description"`
+	Params                map[string]json.RawMessage `json:"params"`
+	MaxActiveRuns         *int64                     `json:"max_active_runs"`
+	MaxActiveTasks        *int64                     `json:"max_active_tasks"`
+	Catchup               bool                       `json:"catchup"`
+	NextDagrun            *string                    `json:"next_dagrun"`
+	NextDagrunLogicalDate *string                    `json:"next_dagrun_logical_date"`
+	LastParsedTime        *string                    `json:"last_parsed_time"`
+	DatasetExpression     json.RawMessage            `json:"dataset_expression"`
+	AssetExpression       json.RawMessage            `json:"asset_expression"`
+}
+
+type dagTag struct {
+	Name string `json:"name"`
+}
+
+type dagScheduleInterval struct {
+	Type    string  `json:"__type"`
+	Value   string  `json:"value"`
+	Days    float64 `json:"days"`
+	Seconds float64 `json:"seconds"`
+}
+
+// String renders cron expressions as is and time deltas as durations.
+func (s *dagScheduleInterval) String() string {
+	switch s.Type {
+	case "CronExpression":
+		return s.Value
+	case "TimeDelta":
+		return (time.Duration(s.Days*24)*time.Hour + time.Duration(s.Seconds)*time.Second).String()
+	default:
+		return ""
+	}
+}
+
+// dagParamDefault returns the default value of a DAG param, which Airflow
+// serializes either as a Param object or, for older versions, as the value.
+func dagParamDefault(raw json.RawMessage) string {
+	var param struct {
+		Class *string         `json:"__class"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(raw, &param); err == nil && (param.Class != nil || param.Value != nil) {
+		raw = param.Value
+	}
+	if len(raw) == 0 {
+		return "null"
+	}
+
+	return string(raw)
+}
+
+// datasetURIs walks a dataset or asset expression, such as
+// {"any": ["s3://a", {"all": [{"uri": "s3://b"}]}]}, for the URIs it depends
+// on.
+func datasetURIs(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var uris []string
+		for _, item := range v {
+			uris = append(uris, datasetURIs(item)...)
+		}
+		return uris
+	case map[string]interface{}:
+		if uri, ok := v["uri"].(string); ok {
+			return []string{uri}
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		// Other string fields of the map, such as the name of an asset
+		// alias, are not URIs.
+		var uris []string
+		for _, k := range keys {
+			if _, ok := v[k].(string); !ok {
+				uris = append(uris, datasetURIs(v[k])...)
+			}
+		}
+		return uris
+	default:
+		return nil
+	}
+}
+
+func newDagDataSource() datasource.DataSource {
+	return &dagDataSource{}
+}
+
+func (d *dagDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dag"
+}
+
+func (d *dagDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the details of a DAG without managing it",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"dag_id": schema.StringAttribute{
+				Required: true,
+			},
+			"description": schema.StringAttribute{
+				Computed: true,
+			},
+			"fileloc": schema.StringAttribute{
+				Computed: true,
+			},
+			"is_active": schema.BoolAttribute{
+				Computed: true,
+			},
+			"is_paused": schema.BoolAttribute{
+				Computed: true,
+			},
+			"owners": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"tags": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"schedule_interval": schema.StringAttribute{
+				Computed: true,
+			},
+			"timetable_summary": schema.StringAttribute{
+				Computed: true,
+			},
+			"timetable_description": schema.StringAttribute{
+				Computed: true,
+			},
+			"params": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The default value of each param, JSON encoded",
+			},
+			"max_active_runs": schema.Int64Attribute{
+				Computed: true,
+			},
+			"max_active_tasks": schema.Int64Attribute{
+				Computed: true,
+			},
+			"catchup": schema.BoolAttribute{
+				Computed: true,
+			},
+			"next_dagrun": schema.StringAttribute{
+				Computed: true,
+			},
+			"last_parsed_time": schema.StringAttribute{
+				Computed: true,
+			},
+			"dataset_expression": schema.StringAttribute{
+				Computed:    true,
+				Description: "The datasets or assets triggering the DAG, JSON encoded",
+			},
+			"dataset_triggers": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *dagDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	d.providerData = req.ProviderData
+}
+
+func (d *dagDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config dagDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pcfg, diags := frameworkProviderConfig(d.providerData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dagId := config.DagId.ValueString()
+
+	ctx, end := startOperation(ctx, pcfg, "airflow_dag", "read", config.DagId.ValueString)
+	defer func() { end(frameworkErrorSummary(resp.Diagnostics)) }()
+
+	_, res, err := pcfg.ApiClient.DAGApi.GetDagDetails(pcfg.AuthContext(ctx), dagId).Execute()
+	if res == nil || res.StatusCode != http.StatusOK {
+		resp.Diagnostics.Append(frameworkDiags(apiErrorf(nil, err, "failed to get DAG `%s` from Airflow", dagId))...)
+		return
+	}
+
+	var details dagDetails
+	if err := decodeResponseBody(res, &details); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to decode DAG `%s` from Airflow: %s", dagId, err), "")
+		return
+	}
+
+	state, diags := flattenDagDetails(ctx, details)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func flattenDagDetails(ctx context.Context, details dagDetails) (dagDataSourceModel, fwdiag.Diagnostics) {
+	var diags, d fwdiag.Diagnostics
+
+	isActive := details.IsActive != nil && *details.IsActive
+	if details.IsStale != nil {
+		isActive = !*details.IsStale
+	}
+
+	var scheduleInterval string
+	if details.ScheduleInterval != nil {
+		scheduleInterval = details.ScheduleInterval.String()
+	}
+
+	nextDagrun := details.NextDagrun
+	if nextDagrun == nil {
+		nextDagrun = details.NextDagrunLogicalDate
+	}
+
+	state := dagDataSourceModel{
+		Id:                   types.StringValue(details.DagId),
+		DagId:                types.StringValue(details.DagId),
+		Description:          types.StringPointerValue(details.Description),
+		Fileloc:              types.StringValue(details.Fileloc),
+		IsActive:             types.BoolValue(isActive),
+		IsPaused:             types.BoolValue(details.IsPaused),
+		ScheduleInterval:     types.StringValue(scheduleInterval),
+		TimetableSummary:     types.StringPointerValue(details.TimetableSummary),
+		TimetableDescription: types.StringPointerValue(details.TimetableDescription),
+		MaxActiveRuns:        types.Int64PointerValue(details.MaxActiveRuns),
+		MaxActiveTasks:       types.Int64PointerValue(details.MaxActiveTasks),
+		Catchup:              types.BoolValue(details.Catchup),
+		NextDagrun:           types.StringPointerValue(nextDagrun),
+		LastParsedTime:       types.StringPointerValue(details.LastParsedTime),
+		DatasetExpression:    types.StringNull(),
+	}
+
+	owners := details.Owners
+	if owners == nil {
+		owners = []string{}
+	}
+	state.Owners, d = types.ListValueFrom(ctx, types.StringType, owners)
+	diags.Append(d...)
+
+	tags := []string{}
+	for _, tag := range details.Tags {
+		tags = append(tags, tag.Name)
+	}
+	state.Tags, d = types.ListValueFrom(ctx, types.StringType, tags)
+	diags.Append(d...)
+
+	params := make(map[string]string, len(details.Params))
+	for name, raw := range details.Params {
+		params[name] = dagParamDefault(raw)
+	}
+	state.Params, d = types.MapValueFrom(ctx, types.StringType, params)
+	diags.Append(d...)
+
+	expression := details.DatasetExpression
+	if len(expression) == 0 || string(expression) == "null" {
+		expression = details.AssetExpression
+	}
+	triggers := []string{}
+	if len(expression) > 0 && string(expression) != "null" {
+		state.DatasetExpression = types.StringValue(string(expression))
+
+		var v interface{}
+		if err := json.Unmarshal(expression, &v); err == nil {
+			triggers = append(triggers, datasetURIs(v)...)
+		}
+	}
+	state.DatasetTriggers, d = types.ListValueFrom(ctx, types.StringType, triggers)
+	diags.Append(d...)
+
+	return state, diags
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAirflowDagDataSource_basic(t *testing.T) {
+	dataSourceName := "data.airflow_dag.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "airflow_dag" "test" {
+  dag_id = "tutorial"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "dag_id", "tutorial"),
+					resource.TestCheckResourceAttr(dataSourceName, "tags.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "tags.0", "example"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "fileloc"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "timetable_description"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "last_parsed_time"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenDagDetails(t *testing.T) {
+	cases := map[string]struct {
+		body             string
+		scheduleInterval string
+		nextDagrun       string
+		isActive         bool
+		params           map[string]string
+		triggers         []string
+	}{
+		"v1": {
+			body: `{
+				"dag_id": "example",
+				"is_active": true,
+				"schedule_interval": {"__type": "TimeDelta", "days": 1, "seconds": 3600, "microseconds": 0},
+				"params": {"env": {"__class": "airflow.models.param.Param", "value": "dev", "description": null}, "retries": 3},
+				"next_dagrun": "2024-01-01T00:00:00+00:00",
+				"dataset_expression": {"any": ["s3://a", {"all": ["s3://b", "s3://c"]}]}
+			}`,
+			scheduleInterval: "25h0m0s",
+			nextDagrun:       "2024-01-01T00:00:00+00:00",
+			isActive:         true,
+			params:           map[string]string{"env": `"dev"`, "retries": "3"},
+			triggers:         []string{"s3://a", "s3://b", "s3://c"},
+		},
+		"v2": {
+			body: `{
+				"dag_id": "example",
+				"is_stale": false,
+				"timetable_summary": "@daily",
+				"params": {"env": {"value": null, "description": "Target", "schema": {}}},
+				"next_dagrun_logical_date": "2024-01-01T00:00:00Z",
+				"asset_expression": {"any": [{"asset": {"uri": "s3://a", "name": "a", "group": "asset"}}, {"alias": {"name": "b", "group": ""}}]}
+			}`,
+			nextDagrun: "2024-01-01T00:00:00Z",
+			isActive:   true,
+			params:     map[string]string{"env": "null"},
+			triggers:   []string{"s3://a"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var details dagDetails
+			if err := json.Unmarshal([]byte(tc.body), &details); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			state, diags := flattenDagDetails(context.Background(), details)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if got := state.ScheduleInterval.ValueString(); got != tc.scheduleInterval {
+				t.Errorf("expected schedule interval %q, got %q", tc.scheduleInterval, got)
+			}
+			if got := state.NextDagrun.ValueString(); got != tc.nextDagrun {
+				t.Errorf("expected next DAG run %q, got %q", tc.nextDagrun, got)
+			}
+			if got := state.IsActive.ValueBool(); got != tc.isActive {
+				t.Errorf("expected is_active %t, got %t", tc.isActive, got)
+			}
+
+			params := make(map[string]string)
+			state.Params.ElementsAs(context.Background(), &params, false)
+			for k, v := range tc.params {
+				if params[k] != v {
+					t.Errorf("expected param %s to default to %s, got %s", k, v, params[k])
+				}
+			}
+
+			var triggers []string
+			state.DatasetTriggers.ElementsAs(context.Background(), &triggers, false)
+			if len(triggers) != len(tc.triggers) {
+				t.Fatalf("expected triggers %v, got %v", tc.triggers, triggers)
+			}
+			for i := range triggers {
+				if triggers[i] != tc.triggers[i] {
+					t.Errorf("expected triggers %v, got %v", tc.triggers, triggers)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSourceWithConfigure = &dagsDataSource{}
+
+type dagsDataSource struct {
+	providerData any
+}
+
+type dagsDataSourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	Tags         types.List   `tfsdk:"tags"`
+	DagIdPattern types.String `tfsdk:"dag_id_pattern"`
+	OnlyActive   types.Bool   `tfsdk:"only_active"`
+	Paused       types.Bool   `tfsdk:"paused"`
+	DagIds       types.List   `tfsdk:"dag_ids"`
+	Dags         types.List   `tfsdk:"dags"`
+}
+
+// dagAttributeTypes are the attributes of the airflow_dag resource.
+var dagAttributeTypes = map[string]attr.Type{
+	"dag_id":      types.StringType,
+	"description": types.StringType,
+	"file_token":  types.StringType,
+	"fileloc":     types.StringType,
+	"is_active":   types.BoolType,
+	"is_paused":   types.BoolType,
+	"is_subdag":   types.BoolType,
+	"root_dag_id": types.StringType,
+}
+
+// dagCollection is decoded from the response body, like dagDetails, with the
+// is_stale field of Airflow 3.
+type dagCollection struct {
+	Dags []struct {
+		DagId       string  `json:"dag_id"`
+		Description *string `json:"description"`
+		FileToken   string  `json:"file_token"`
+		Fileloc     string  `json:"fileloc"`
+		IsActive    *bool   `json:"is_active"`
+		IsStale     *bool   `json:"is_stale"`
+		IsPaused    bool    `json:"is_paused"`
+		IsSubdag    bool    `json:"is_subdag"`
+		RootDagId   *string `json:"root_dag_id"`
+	} `json:"dags"`
+	TotalEntries int32 `json:"total_entries"`
+}
+
+func newDagsDataSource() datasource.DataSource {
+	return &dagsDataSource{}
+}
+
+func (d *dagsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dags"
+}
+
+func (d *dagsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the DAGs matching the given filters",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"tags": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Only list the DAGs with any of these tags",
+			},
+			"dag_id_pattern": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the DAGs whose ID contains this pattern, with SQL LIKE wildcards",
+			},
+			"only_active": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to only list the DAGs whose file is still present, defaults to true",
+			},
+			"paused": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only list the paused DAGs when true, or the unpaused DAGs when false",
+			},
+			"dag_ids": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			// Protocol version 5 has no nested attributes, the DAGs are a list
+			// of objects instead.
+			"dags": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.ObjectType{AttrTypes: dagAttributeTypes},
+			},
+		},
+	}
+}
+
+func (d *dagsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	d.providerData = req.ProviderData
+}
+
+func (d *dagsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config dagsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pcfg, diags := frameworkProviderConfig(d.providerData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tags []string
+	resp.Diagnostics.Append(config.Tags.ElementsAs(ctx, &tags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	onlyActive := config.OnlyActive.IsNull() || config.OnlyActive.ValueBool()
+
+	paused := ""
+	if !config.Paused.IsNull() {
+		paused = strconv.FormatBool(config.Paused.ValueBool())
+	}
+
+	id := fmt.Sprintf("%s/%s/%t/%s", strings.Join(tags, ","), config.DagIdPattern.ValueString(), onlyActive, paused)
+
+	ctx, end := startOperation(ctx, pcfg, "airflow_dags", "read", func() string { return id })
+	defer func() { end(frameworkErrorSummary(resp.Diagnostics)) }()
+
+	dagIds := []string{}
+	dags := []attr.Value{}
+	err := listAll(func(offset int32) (int, int32, error) {
+		request := pcfg.ApiClient.DAGApi.GetDags(pcfg.AuthContext(ctx)).
+			Limit(pageLimit).
+			Offset(offset).
+			OrderBy("dag_id").
+			OnlyActive(onlyActive)
+		if len(tags) > 0 {
+			request = request.Tags(tags)
+		}
+		if v := config.DagIdPattern.ValueString(); v != "" {
+			request = request.DagIdPattern(v)
+		}
+
+		_, res, err := request.Execute()
+		if res == nil || res.StatusCode != http.StatusOK {
+			return 0, 0, err
+		}
+
+		var page dagCollection
+		if err := decodeResponseBody(res, &page); err != nil {
+			return 0, 0, fmt.Errorf("failed to decode DAGs: %s", err)
+		}
+
+		for _, dag := range page.Dags {
+			isActive := dag.IsActive != nil && *dag.IsActive
+			if dag.IsStale != nil {
+				isActive = !*dag.IsStale
+			}
+
+			// The client cannot send the paused filter of Airflow 2.6 and
+			// Airflow 3 ignores only_active, both are applied here.
+			if onlyActive && !isActive {
+				continue
+			}
+			if !config.Paused.IsNull() && dag.IsPaused != config.Paused.ValueBool() {
+				continue
+			}
+
+			value, diags := types.ObjectValue(dagAttributeTypes, map[string]attr.Value{
+				"dag_id":      types.StringValue(dag.DagId),
+				"description": types.StringPointerValue(dag.Description),
+				"file_token":  types.StringValue(dag.FileToken),
+				"fileloc":     types.StringValue(dag.Fileloc),
+				"is_active":   types.BoolValue(isActive),
+				"is_paused":   types.BoolValue(dag.IsPaused),
+				"is_subdag":   types.BoolValue(dag.IsSubdag),
+				"root_dag_id": types.StringPointerValue(dag.RootDagId),
+			})
+			resp.Diagnostics.Append(diags...)
+
+			dagIds = append(dagIds, dag.DagId)
+			dags = append(dags, value)
+		}
+
+		return len(page.Dags), page.TotalEntries, nil
+	})
+	if err != nil {
+		resp.Diagnostics.Append(frameworkDiags(apiErrorf(nil, err, "failed to list DAGs from Airflow"))...)
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := config
+	state.Id = types.StringValue(id)
+	state.DagIds, diags = types.ListValueFrom(ctx, types.StringType, dagIds)
+	resp.Diagnostics.Append(diags...)
+	state.Dags, diags = types.ListValue(types.ObjectType{AttrTypes: dagAttributeTypes}, dags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
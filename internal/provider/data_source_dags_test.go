@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAirflowDagsDataSource_basic(t *testing.T) {
+	dataSourceName := "data.airflow_dags.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "airflow_dags" "test" {
+  tags           = ["example"]
+  dag_id_pattern = "tutorial"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", "example/tutorial/true/"),
+					resource.TestCheckTypeSetElemAttr(dataSourceName, "dag_ids.*", "tutorial"),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "dags.*", map[string]string{
+						"dag_id":    "tutorial",
+						"is_active": "true",
+					}),
+				),
+			},
+		},
+	})
+}
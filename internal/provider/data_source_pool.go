@@ -0,0 +1,277 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &poolDataSource{}
+	_ datasource.DataSourceWithConfigure = &poolsDataSource{}
+)
+
+// poolAttributeTypes are the attributes of the airflow_pool resource, with the
+// description.
+var poolAttributeTypes = map[string]attr.Type{
+	"name":           types.StringType,
+	"description":    types.StringType,
+	"slots":          types.Int64Type,
+	"occupied_slots": types.Int64Type,
+	"used_slots":     types.Int64Type,
+	"queued_slots":   types.Int64Type,
+	"open_slots":     types.Int64Type,
+}
+
+// poolStats is decoded from the response body for the running_slots field
+// that replaced used_slots in Airflow 3.
+type poolStats struct {
+	Name          string  `json:"name"`
+	Description   *string `json:"description"`
+	Slots         int64   `json:"slots"`
+	OccupiedSlots int64   `json:"occupied_slots"`
+	UsedSlots     *int64  `json:"used_slots"`
+	RunningSlots  *int64  `json:"running_slots"`
+	QueuedSlots   int64   `json:"queued_slots"`
+	OpenSlots     int64   `json:"open_slots"`
+}
+
+func (p poolStats) usedSlots() *int64 {
+	if p.RunningSlots != nil {
+		return p.RunningSlots
+	}
+
+	return p.UsedSlots
+}
+
+func (p poolStats) description(pcfg ProviderConfig) string {
+	if p.Description == nil {
+		return ""
+	}
+
+	return pcfg.withoutManagedByMarker(*p.Description)
+}
+
+func (p poolStats) attributes(pcfg ProviderConfig) map[string]attr.Value {
+	return map[string]attr.Value{
+		"name":           types.StringValue(p.Name),
+		"description":    types.StringValue(p.description(pcfg)),
+		"slots":          types.Int64Value(p.Slots),
+		"occupied_slots": types.Int64Value(p.OccupiedSlots),
+		"used_slots":     types.Int64PointerValue(p.usedSlots()),
+		"queued_slots":   types.Int64Value(p.QueuedSlots),
+		"open_slots":     types.Int64Value(p.OpenSlots),
+	}
+}
+
+type poolDataSource struct {
+	providerData any
+}
+
+type poolDataSourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Description   types.String `tfsdk:"description"`
+	Slots         types.Int64  `tfsdk:"slots"`
+	OccupiedSlots types.Int64  `tfsdk:"occupied_slots"`
+	UsedSlots     types.Int64  `tfsdk:"used_slots"`
+	QueuedSlots   types.Int64  `tfsdk:"queued_slots"`
+	OpenSlots     types.Int64  `tfsdk:"open_slots"`
+}
+
+func newPoolDataSource() datasource.DataSource {
+	return &poolDataSource{}
+}
+
+func (d *poolDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pool"
+}
+
+func (d *poolDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads a pool and its slot usage without managing it",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"description": schema.StringAttribute{
+				Computed: true,
+			},
+			"slots": schema.Int64Attribute{
+				Computed: true,
+			},
+			"occupied_slots": schema.Int64Attribute{
+				Computed: true,
+			},
+			"used_slots": schema.Int64Attribute{
+				Computed: true,
+			},
+			"queued_slots": schema.Int64Attribute{
+				Computed: true,
+			},
+			"open_slots": schema.Int64Attribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *poolDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	d.providerData = req.ProviderData
+}
+
+func (d *poolDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config poolDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pcfg, diags := frameworkProviderConfig(d.providerData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := config.Name.ValueString()
+
+	ctx, end := startOperation(ctx, pcfg, "airflow_pool", "read", config.Name.ValueString)
+	defer func() { end(frameworkErrorSummary(resp.Diagnostics)) }()
+
+	_, res, err := pcfg.ApiClient.PoolApi.GetPool(pcfg.AuthContext(ctx), name).Execute()
+	if res == nil || res.StatusCode != http.StatusOK {
+		resp.Diagnostics.Append(frameworkDiags(apiErrorf(nil, err, "failed to get pool `%s` from Airflow", name))...)
+		return
+	}
+
+	var pool poolStats
+	if err := decodeResponseBody(res, &pool); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to decode pool `%s` from Airflow: %s", name, err), "")
+		return
+	}
+
+	state := poolDataSourceModel{
+		Id:            types.StringValue(pool.Name),
+		Name:          types.StringValue(pool.Name),
+		Description:   types.StringValue(pool.description(pcfg)),
+		Slots:         types.Int64Value(pool.Slots),
+		OccupiedSlots: types.Int64Value(pool.OccupiedSlots),
+		UsedSlots:     types.Int64PointerValue(pool.usedSlots()),
+		QueuedSlots:   types.Int64Value(pool.QueuedSlots),
+		OpenSlots:     types.Int64Value(pool.OpenSlots),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+type poolsDataSource struct {
+	providerData any
+}
+
+type poolsDataSourceModel struct {
+	Id    types.String `tfsdk:"id"`
+	Names types.List   `tfsdk:"names"`
+	Pools types.List   `tfsdk:"pools"`
+}
+
+// poolCollection is decoded from the response body like poolStats.
+type poolCollection struct {
+	Pools        []poolStats `json:"pools"`
+	TotalEntries int32       `json:"total_entries"`
+}
+
+func newPoolsDataSource() datasource.DataSource {
+	return &poolsDataSource{}
+}
+
+func (d *poolsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pools"
+}
+
+func (d *poolsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads every pool and its slot usage",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"names": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			// Protocol version 5 has no nested attributes, the pools are a
+			// list of objects instead.
+			"pools": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.ObjectType{AttrTypes: poolAttributeTypes},
+			},
+		},
+	}
+}
+
+func (d *poolsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	d.providerData = req.ProviderData
+}
+
+func (d *poolsDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	pcfg, diags := frameworkProviderConfig(d.providerData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, end := startOperation(ctx, pcfg, "airflow_pools", "read", func() string { return "" })
+	defer func() { end(frameworkErrorSummary(resp.Diagnostics)) }()
+
+	names := []string{}
+	pools := []attr.Value{}
+	err := listAll(func(offset int32) (int, int32, error) {
+		_, res, err := pcfg.ApiClient.PoolApi.GetPools(pcfg.AuthContext(ctx)).Limit(pageLimit).Offset(offset).Execute()
+		if res == nil || res.StatusCode != http.StatusOK {
+			return 0, 0, err
+		}
+
+		var page poolCollection
+		if err := decodeResponseBody(res, &page); err != nil {
+			return 0, 0, fmt.Errorf("failed to decode pools: %s", err)
+		}
+
+		for _, pool := range page.Pools {
+			object, diags := types.ObjectValue(poolAttributeTypes, pool.attributes(pcfg))
+			resp.Diagnostics.Append(diags...)
+
+			names = append(names, pool.Name)
+			pools = append(pools, object)
+		}
+
+		return len(page.Pools), page.TotalEntries, nil
+	})
+	if err != nil {
+		resp.Diagnostics.Append(frameworkDiags(apiErrorf(nil, err, "failed to list pools from Airflow"))...)
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := poolsDataSourceModel{
+		Id: types.StringValue("pools"),
+	}
+	state.Names, diags = types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	state.Pools, diags = types.ListValue(types.ObjectType{AttrTypes: poolAttributeTypes}, pools)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAirflowPoolDataSources_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckAirflowPoolCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAirflowPoolDataSourcesConfigBasic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.airflow_pool.default", "name", "default_pool"),
+					resource.TestCheckResourceAttrSet("data.airflow_pool.default", "open_slots"),
+					resource.TestCheckResourceAttr("data.airflow_pool.test", "slots", "3"),
+					resource.TestCheckResourceAttr("data.airflow_pool.test", "open_slots", "3"),
+					resource.TestCheckTypeSetElemAttr("data.airflow_pools.test", "names.*", rName),
+					resource.TestCheckTypeSetElemNestedAttrs("data.airflow_pools.test", "pools.*", map[string]string{
+						"name":       rName,
+						"slots":      "3",
+						"used_slots": "0",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccAirflowPoolDataSourcesConfigBasic(rName string) string {
+	return fmt.Sprintf(`
+resource "airflow_pool" "test" {
+  name  = %[1]q
+  slots = 3
+}
+
+data "airflow_pool" "default" {
+  name = "default_pool"
+}
+
+data "airflow_pool" "test" {
+  name = airflow_pool.test.name
+}
+
+data "airflow_pools" "test" {
+  depends_on = [airflow_pool.test]
+}
+`, rName)
+}
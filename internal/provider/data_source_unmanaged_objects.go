@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSourceWithConfigure = &unmanagedObjectsDataSource{}
+
+type unmanagedObjectsDataSource struct {
+	providerData any
+}
+
+type unmanagedObjectsDataSourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	Marker        types.String `tfsdk:"marker"`
+	ConnectionIds types.List   `tfsdk:"connection_ids"`
+	PoolNames     types.List   `tfsdk:"pool_names"`
+	VariableKeys  types.List   `tfsdk:"variable_keys"`
+}
+
+func newUnmanagedObjectsDataSource() datasource.DataSource {
+	return &unmanagedObjectsDataSource{}
+}
+
+func (d *unmanagedObjectsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_unmanaged_objects"
+}
+
+func (d *unmanagedObjectsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the connections, pools and variables whose description lacks the managed-by marker",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"marker": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The marker to look for, defaults to the `managed_by_marker` of the provider",
+			},
+			"connection_ids": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Null before Airflow 2.3, which cannot mark connections",
+			},
+			"pool_names": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Null before Airflow 2.3, which cannot mark pools",
+			},
+			"variable_keys": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Null before Airflow 2.4, which cannot mark variables",
+			},
+		},
+	}
+}
+
+func (d *unmanagedObjectsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	d.providerData = req.ProviderData
+}
+
+func (d *unmanagedObjectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config unmanagedObjectsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pcfg, diags := frameworkProviderConfig(d.providerData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	marker := pcfg.managedByMarker
+	if v := config.Marker.ValueString(); v != "" {
+		marker = v
+	}
+	if marker == "" {
+		resp.Diagnostics.AddError("no managed-by marker", "set `marker` or the `managed_by_marker` of the provider")
+		return
+	}
+
+	ctx, end := startOperation(ctx, pcfg, "airflow_unmanaged_objects", "read", func() string { return marker })
+	defer func() { end(frameworkErrorSummary(resp.Diagnostics)) }()
+
+	client := pcfg.ApiClient
+	state := unmanagedObjectsDataSourceModel{
+		Id:            types.StringValue(marker),
+		Marker:        types.StringValue(marker),
+		ConnectionIds: types.ListNull(types.StringType),
+		PoolNames:     types.ListNull(types.StringType),
+		VariableKeys:  types.ListNull(types.StringType),
+	}
+
+	// The object types without a description on this Airflow version cannot
+	// carry the marker, their list is left null rather than listing them all.
+	if pcfg.supportsVersion(connectionDescriptionMinVersion) {
+		connectionIds := []string{}
+		err := listAll(func(offset int32) (int, int32, error) {
+			page, _, err := client.ConnectionApi.GetConnections(pcfg.AuthContext(ctx)).Limit(pageLimit).Offset(offset).Execute()
+			if err != nil {
+				return 0, 0, err
+			}
+			for _, connection := range page.GetConnections() {
+				if !hasManagedByMarker(connection.GetDescription(), marker) {
+					connectionIds = append(connectionIds, connection.GetConnectionId())
+				}
+			}
+			return len(page.GetConnections()), page.GetTotalEntries(), nil
+		})
+		if err != nil {
+			resp.Diagnostics.Append(frameworkDiags(apiErrorf(nil, err, "failed to list connections from Airflow"))...)
+			return
+		}
+		state.ConnectionIds, diags = types.ListValueFrom(ctx, types.StringType, connectionIds)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if pcfg.supportsVersion(poolDescriptionMinVersion) {
+		poolNames := []string{}
+		err := listAll(func(offset int32) (int, int32, error) {
+			page, _, err := client.PoolApi.GetPools(pcfg.AuthContext(ctx)).Limit(pageLimit).Offset(offset).Execute()
+			if err != nil {
+				return 0, 0, err
+			}
+			for _, pool := range page.GetPools() {
+				if !hasManagedByMarker(pool.GetDescription(), marker) {
+					poolNames = append(poolNames, pool.GetName())
+				}
+			}
+			return len(page.GetPools()), page.GetTotalEntries(), nil
+		})
+		if err != nil {
+			resp.Diagnostics.Append(frameworkDiags(apiErrorf(nil, err, "failed to list pools from Airflow"))...)
+			return
+		}
+		state.PoolNames, diags = types.ListValueFrom(ctx, types.StringType, poolNames)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if pcfg.supportsVersion(variableDescriptionMinVersion) {
+		variableKeys := []string{}
+		err := listAll(func(offset int32) (int, int32, error) {
+			page, _, err := client.VariableApi.GetVariables(pcfg.AuthContext(ctx)).Limit(pageLimit).Offset(offset).Execute()
+			if err != nil {
+				return 0, 0, err
+			}
+			for _, variable := range page.GetVariables() {
+				if !hasManagedByMarker(variable.GetDescription(), marker) {
+					variableKeys = append(variableKeys, variable.GetKey())
+				}
+			}
+			return len(page.GetVariables()), page.GetTotalEntries(), nil
+		})
+		if err != nil {
+			resp.Diagnostics.Append(frameworkDiags(apiErrorf(nil, err, "failed to list variables from Airflow"))...)
+			return
+		}
+		state.VariableKeys, diags = types.ListValueFrom(ctx, types.StringType, variableKeys)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAirflowUnmanagedObjects_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	dataSourceName := "data.airflow_unmanaged_objects.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckAirflowPoolCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAirflowUnmanagedObjectsConfigBasic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "marker", "[managed by terraform]"),
+					resource.TestCheckTypeSetElemAttr(dataSourceName, "pool_names.*", "default_pool"),
+					resource.TestCheckResourceAttr("airflow_pool.test", "name", rName),
+				),
+			},
+		},
+	})
+}
+
+func TestUnmanagedObjectsDataSourceRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/connections":
+			fmt.Fprint(w, `{"connections": [{"connection_id": "a", "description": "[managed]"}, {"connection_id": "b"}], "total_entries": 2}`)
+		case "/api/v1/pools":
+			fmt.Fprint(w, `{"pools": [{"name": "default_pool"}], "total_entries": 1}`)
+		case "/api/v1/variables":
+			fmt.Fprint(w, `{"variables": [{"key": "c"}], "total_entries": 1}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		version       string
+		connectionIds []string
+		poolNames     []string
+		variableKeys  []string
+	}{
+		{"2.2.5", nil, nil, nil},
+		{"2.3.4", []string{"b"}, []string{"default_pool"}, nil},
+		{"2.4.0", []string{"b"}, []string{"default_pool"}, []string{"c"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.version, func(t *testing.T) {
+			u, _ := url.Parse(server.URL)
+			sdk := AirflowProvider()
+			sdk.SetMeta(ProviderConfig{
+				ApiClient:       newApiClient(u, apiServerPath("", apiVersionV1), server.Client(), false),
+				AirflowVersion:  version.Must(version.NewVersion(tc.version)),
+				managedByMarker: "[managed]",
+			})
+
+			ctx := context.Background()
+			d := &unmanagedObjectsDataSource{providerData: sdk}
+
+			var schemaResp datasource.SchemaResponse
+			d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+			objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+			attributes := make(map[string]tftypes.Value)
+			for name, typ := range objectType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(typ, nil)
+			}
+
+			req := datasource.ReadRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)},
+			}
+			resp := datasource.ReadResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+			}
+			d.Read(ctx, req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var state unmanagedObjectsDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			lists := map[string]struct {
+				list     types.List
+				expected []string
+			}{
+				"connection_ids": {state.ConnectionIds, tc.connectionIds},
+				"pool_names":     {state.PoolNames, tc.poolNames},
+				"variable_keys":  {state.VariableKeys, tc.variableKeys},
+			}
+			for name, l := range lists {
+				if l.expected == nil {
+					if !l.list.IsNull() {
+						t.Errorf("expected %s to be null", name)
+					}
+					continue
+				}
+				var got []string
+				l.list.ElementsAs(ctx, &got, false)
+				if fmt.Sprint(got) != fmt.Sprint(l.expected) {
+					t.Errorf("expected %s to be %v, got %v", name, l.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func testAccAirflowUnmanagedObjectsConfigBasic(rName string) string {
+	return fmt.Sprintf(`
+provider "airflow" {
+  managed_by_marker = "[managed by terraform]"
+}
+
+resource "airflow_pool" "test" {
+  name  = %[1]q
+  slots = 1
+}
+
+data "airflow_unmanaged_objects" "test" {
+  depends_on = [airflow_pool.test]
+}
+`, rName)
+}
@@ -0,0 +1,382 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &variableDataSource{}
+	_ datasource.DataSourceWithConfigure = &variablesDataSource{}
+)
+
+type variableDataSource struct {
+	providerData any
+}
+
+type variableDataSourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Key         types.String `tfsdk:"key"`
+	Value       types.String `tfsdk:"value"`
+	Description types.String `tfsdk:"description"`
+}
+
+func newVariableDataSource() datasource.DataSource {
+	return &variableDataSource{}
+}
+
+func (d *variableDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_variable"
+}
+
+func (d *variableDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads a variable without managing it",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"key": schema.StringAttribute{
+				Required: true,
+			},
+			"value": schema.StringAttribute{
+				Computed: true,
+			},
+			"description": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *variableDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	d.providerData = req.ProviderData
+}
+
+func (d *variableDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config variableDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pcfg, diags := frameworkProviderConfig(d.providerData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key := config.Key.ValueString()
+
+	ctx, end := startOperation(ctx, pcfg, "airflow_variable", "read", config.Key.ValueString)
+	defer func() { end(frameworkErrorSummary(resp.Diagnostics)) }()
+
+	variable, _, err := pcfg.ApiClient.VariableApi.GetVariable(pcfg.AuthContext(ctx), key).Execute()
+	if err != nil {
+		resp.Diagnostics.Append(frameworkDiags(apiErrorf(nil, err, "failed to get variable `%s` from Airflow", key))...)
+		return
+	}
+
+	state := variableDataSourceModel{
+		Id:          types.StringValue(key),
+		Key:         types.StringValue(key),
+		Value:       types.StringValue(variable.GetValue()),
+		Description: types.StringValue(pcfg.withoutManagedByMarker(variable.GetDescription())),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+type variablesDataSource struct {
+	providerData any
+}
+
+type variablesDataSourceModel struct {
+	Id                     types.String  `tfsdk:"id"`
+	KeyPrefix              types.String  `tfsdk:"key_prefix"`
+	KeyRegex               types.String  `tfsdk:"key_regex"`
+	Sensitive              types.Bool    `tfsdk:"sensitive"`
+	JsonDecode             types.Bool    `tfsdk:"json_decode"`
+	Keys                   types.List    `tfsdk:"keys"`
+	Values                 types.Map     `tfsdk:"values"`
+	SensitiveValues        types.Map     `tfsdk:"sensitive_values"`
+	DecodedValues          types.Dynamic `tfsdk:"decoded_values"`
+	SensitiveDecodedValues types.Dynamic `tfsdk:"sensitive_decoded_values"`
+}
+
+// variableCollection is decoded from the response body, as Airflow 3 and some
+// versions of Airflow 2 list the values that the model of the generated client
+// leaves out.
+type variableCollection struct {
+	Variables []struct {
+		Key   string  `json:"key"`
+		Value *string `json:"value"`
+	} `json:"variables"`
+	TotalEntries int32 `json:"total_entries"`
+}
+
+// jsonAttrValue converts a decoded JSON value, with numbers decoded as
+// json.Number, to a Terraform value. Objects become objects rather than maps as
+// their values may differ in type.
+func jsonAttrValue(v interface{}) (attr.Value, error) {
+	switch v := v.(type) {
+	case nil:
+		return types.StringNull(), nil
+	case bool:
+		return types.BoolValue(v), nil
+	case string:
+		return types.StringValue(v), nil
+	case json.Number:
+		f, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, err
+		}
+		return types.NumberValue(f), nil
+	case []interface{}:
+		elemTypes := make([]attr.Type, 0, len(v))
+		elems := make([]attr.Value, 0, len(v))
+		for _, item := range v {
+			elem, err := jsonAttrValue(item)
+			if err != nil {
+				return nil, err
+			}
+			elemTypes = append(elemTypes, elem.Type(context.Background()))
+			elems = append(elems, elem)
+		}
+		value, diags := types.TupleValue(elemTypes, elems)
+		return value, diagsError(diags)
+	case map[string]interface{}:
+		attrTypes := make(map[string]attr.Type, len(v))
+		attrs := make(map[string]attr.Value, len(v))
+		for k, item := range v {
+			value, err := jsonAttrValue(item)
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[k] = value.Type(context.Background())
+			attrs[k] = value
+		}
+		value, diags := types.ObjectValue(attrTypes, attrs)
+		return value, diagsError(diags)
+	default:
+		return nil, fmt.Errorf("unexpected JSON value %T", v)
+	}
+}
+
+// decodeVariableValue decodes a variable holding a single JSON value, with
+// numbers as json.Number. Other values, such as plain strings, are returned
+// as is.
+func decodeVariableValue(value string) interface{} {
+	if !json.Valid([]byte(value)) {
+		return value
+	}
+
+	decoder := json.NewDecoder(bytes.NewBufferString(value))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return value
+	}
+
+	return v
+}
+
+func diagsError(diags fwdiag.Diagnostics) error {
+	for _, d := range diags.Errors() {
+		return fmt.Errorf("%s: %s", d.Summary(), d.Detail())
+	}
+
+	return nil
+}
+
+func newVariablesDataSource() datasource.DataSource {
+	return &variablesDataSource{}
+}
+
+func (d *variablesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_variables"
+}
+
+func (d *variablesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the variables whose key matches a prefix or a regular expression",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"key_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only read the variables whose key starts with this prefix",
+			},
+			"key_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only read the variables whose key matches this regular expression",
+			},
+			"sensitive": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to return the values in the sensitive attributes instead",
+			},
+			"json_decode": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to also return the values decoded from JSON, keeping the values that are not JSON as strings",
+			},
+			"keys": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"values": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"sensitive_values": schema.MapAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+			},
+			"decoded_values": schema.DynamicAttribute{
+				Computed: true,
+			},
+			"sensitive_decoded_values": schema.DynamicAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func (d *variablesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	d.providerData = req.ProviderData
+}
+
+func (d *variablesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config variablesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pcfg, diags := frameworkProviderConfig(d.providerData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prefix := config.KeyPrefix.ValueString()
+	var keyRegex *regexp.Regexp
+	if v := config.KeyRegex.ValueString(); v != "" {
+		var err error
+		if keyRegex, err = regexp.Compile(v); err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("invalid key_regex: %s", err), "")
+			return
+		}
+	}
+
+	id := prefix + "/" + config.KeyRegex.ValueString()
+
+	ctx, end := startOperation(ctx, pcfg, "airflow_variables", "read", func() string { return id })
+	defer func() { end(frameworkErrorSummary(resp.Diagnostics)) }()
+
+	client := pcfg.ApiClient
+
+	values := make(map[string]string)
+	var unlisted []string
+	err := listAll(func(offset int32) (int, int32, error) {
+		_, res, err := client.VariableApi.GetVariables(pcfg.AuthContext(ctx)).Limit(pageLimit).Offset(offset).OrderBy("key").Execute()
+		if res == nil || res.StatusCode != http.StatusOK {
+			return 0, 0, err
+		}
+
+		var page variableCollection
+		if err := decodeResponseBody(res, &page); err != nil {
+			return 0, 0, fmt.Errorf("failed to decode variables: %s", err)
+		}
+
+		for _, variable := range page.Variables {
+			if !strings.HasPrefix(variable.Key, prefix) || (keyRegex != nil && !keyRegex.MatchString(variable.Key)) {
+				continue
+			}
+			if variable.Value == nil {
+				unlisted = append(unlisted, variable.Key)
+				continue
+			}
+			values[variable.Key] = *variable.Value
+		}
+
+		return len(page.Variables), page.TotalEntries, nil
+	})
+	if err != nil {
+		resp.Diagnostics.Append(frameworkDiags(apiErrorf(nil, err, "failed to list variables from Airflow"))...)
+		return
+	}
+
+	// Airflow 2 leaves the values out of the list of variables.
+	for _, key := range unlisted {
+		variable, _, err := client.VariableApi.GetVariable(pcfg.AuthContext(ctx), key).Execute()
+		if err != nil {
+			resp.Diagnostics.Append(frameworkDiags(apiErrorf(nil, err, "failed to get variable `%s` from Airflow", key))...)
+			return
+		}
+		values[key] = variable.GetValue()
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	state := config
+	state.Id = types.StringValue(id)
+	state.Values = types.MapNull(types.StringType)
+	state.SensitiveValues = types.MapNull(types.StringType)
+	state.DecodedValues = types.DynamicNull()
+	state.SensitiveDecodedValues = types.DynamicNull()
+
+	state.Keys, diags = types.ListValueFrom(ctx, types.StringType, keys)
+	resp.Diagnostics.Append(diags...)
+
+	mapValue, diags := types.MapValueFrom(ctx, types.StringType, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	decodedValue := types.DynamicNull()
+	if config.JsonDecode.ValueBool() {
+		decoded := make(map[string]interface{}, len(values))
+		for key, value := range values {
+			decoded[key] = decodeVariableValue(value)
+		}
+
+		value, err := jsonAttrValue(decoded)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("failed to decode variables from Airflow: %s", err), "")
+			return
+		}
+		decodedValue = types.DynamicValue(value)
+	}
+
+	if config.Sensitive.ValueBool() {
+		state.SensitiveValues = mapValue
+		state.SensitiveDecodedValues = decodedValue
+	} else {
+		state.Values = mapValue
+		state.DecodedValues = decodedValue
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAirflowVariableDataSources_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckAirflowVariableCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAirflowVariableDataSourcesConfigBasic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.airflow_variable.test", "value", `{"slots":2}`),
+					resource.TestCheckResourceAttr("data.airflow_variables.test", "keys.#", "1"),
+					resource.TestCheckResourceAttr("data.airflow_variables.test", fmt.Sprintf("values.%s", rName), `{"slots":2}`),
+				),
+			},
+		},
+	})
+}
+
+func TestVariablesDataSourceRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/v1/variables" && r.URL.Query().Get("offset") == "0":
+			fmt.Fprint(w, `{"variables": [{"key": "app_a"}, {"key": "app_b"}, {"key": "app_c"}, {"key": "app_d"}], "total_entries": 5}`)
+		case r.URL.Path == "/api/v1/variables":
+			fmt.Fprint(w, `{"variables": [{"key": "other"}], "total_entries": 5}`)
+		case r.URL.Path == "/api/v1/variables/app_a":
+			fmt.Fprint(w, `{"key": "app_a", "value": "{\"slots\": 2, \"tags\": [\"x\"]}"}`)
+		case r.URL.Path == "/api/v1/variables/app_b":
+			fmt.Fprint(w, `{"key": "app_b", "value": "true"}`)
+		case r.URL.Path == "/api/v1/variables/app_c":
+			fmt.Fprint(w, `{"key": "app_c", "value": "prod"}`)
+		case r.URL.Path == "/api/v1/variables/app_d":
+			fmt.Fprint(w, `{"key": "app_d", "value": "1 2"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	sdk := AirflowProvider()
+	sdk.SetMeta(ProviderConfig{
+		ApiClient: newApiClient(u, apiServerPath("", apiVersionV1), server.Client(), false),
+	})
+
+	ctx := context.Background()
+	d := &variablesDataSource{providerData: sdk}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attributes := make(map[string]tftypes.Value)
+	for name, typ := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(typ, nil)
+	}
+	attributes["key_prefix"] = tftypes.NewValue(tftypes.String, "app_")
+	attributes["sensitive"] = tftypes.NewValue(tftypes.Bool, true)
+	attributes["json_decode"] = tftypes.NewValue(tftypes.Bool, true)
+
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)},
+	}
+	resp := datasource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	d.Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state variablesDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if !state.Values.IsNull() || !state.DecodedValues.IsNull() {
+		t.Error("expected the values to only be in the sensitive attributes")
+	}
+	values := make(map[string]string)
+	state.SensitiveValues.ElementsAs(ctx, &values, false)
+	if len(values) != 4 || values["app_b"] != "true" {
+		t.Errorf("unexpected values %v", values)
+	}
+
+	decoded, _ := state.SensitiveDecodedValues.UnderlyingValue().(types.Object)
+	appA, _ := decoded.Attributes()["app_a"].(types.Object)
+	slots, ok := appA.Attributes()["slots"].(types.Number)
+	if !ok || slots.ValueBigFloat().Cmp(big.NewFloat(2)) != 0 {
+		t.Errorf("expected slots to be decoded as 2, got %s", decoded)
+	}
+	if appB, ok := decoded.Attributes()["app_b"].(types.Bool); !ok || !appB.ValueBool() {
+		t.Errorf("expected app_b to be decoded as true, got %s", decoded)
+	}
+	for key, value := range map[string]string{"app_c": "prod", "app_d": "1 2"} {
+		if v, ok := decoded.Attributes()[key].(types.String); !ok || v.ValueString() != value {
+			t.Errorf("expected %s to be kept as %q, got %s", key, value, decoded)
+		}
+	}
+}
+
+func testAccAirflowVariableDataSourcesConfigBasic(rName string) string {
+	return fmt.Sprintf(`
+resource "airflow_variable" "test" {
+  key   = %[1]q
+  value = jsonencode({ slots = 2 })
+}
+
+data "airflow_variable" "test" {
+  key = airflow_variable.test.key
+}
+
+data "airflow_variables" "test" {
+  key_prefix = %[1]q
+
+  depends_on = [airflow_variable.test]
+}
+`, rName)
+}
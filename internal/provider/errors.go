@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/apache/airflow-client-go/airflow"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// apiProblem is an RFC 7807 problem detail as returned by the Airflow 2 API.
+// Airflow 3 only sends detail, either as a string or as a list of validation
+// errors.
+type apiProblem struct {
+	Type   string
+	Title  string
+	Status int
+	Detail string
+	Field  string
+}
+
+type apiValidationError struct {
+	Loc []interface{} `json:"loc"`
+	Msg string        `json:"msg"`
+}
+
+var (
+	// Schema validation errors end with the offending field, such as
+	// "'abc' is not of type 'integer' - 'slots'".
+	problemTrailingField = regexp.MustCompile(`- '([a-z_]+)'$`)
+	// Marshmallow validation errors are a dict of field to messages, such as
+	// "{'slots': ['Not a valid integer.']}".
+	problemFieldDict = regexp.MustCompile(`^\{'([a-z_]+)': \[`)
+)
+
+// apiResponseError is returned by sendApiRequest for responses outside of the
+// 2xx range. Like the errors of the generated client it keeps the raw body.
+type apiResponseError struct {
+	status string
+	body   []byte
+}
+
+func (e *apiResponseError) Error() string {
+	return e.status
+}
+
+// Body returns the raw body of the response.
+func (e *apiResponseError) Body() []byte {
+	return e.body
+}
+
+// parseApiProblem extracts the problem detail from an error returned by the
+// generated client or by sendApiRequest. It returns nil for errors that are
+// not API responses, such as connection failures.
+func parseApiProblem(err error) *apiProblem {
+	var respStatus string
+	var respBody []byte
+
+	var apiErr airflow.GenericOpenAPIError
+	var respErr *apiResponseError
+	switch {
+	case errors.As(err, &apiErr):
+		respStatus, respBody = apiErr.Error(), apiErr.Body()
+	case errors.As(err, &respErr):
+		respStatus, respBody = respErr.Error(), respErr.Body()
+	default:
+		return nil
+	}
+
+	var body struct {
+		Type   string          `json:"type"`
+		Title  string          `json:"title"`
+		Status int             `json:"status"`
+		Detail json.RawMessage `json:"detail"`
+	}
+	json.Unmarshal(respBody, &body) //nolint:errcheck
+
+	// The client fails to decode application/problem+json bodies, the status
+	// is then only found in the body itself.
+	status := body.Status
+	if status == 0 {
+		status, _ = strconv.Atoi(strings.SplitN(respStatus, " ", 2)[0])
+	}
+	if status == 0 {
+		return nil
+	}
+
+	problem := &apiProblem{
+		Status: status,
+		Title:  http.StatusText(status),
+	}
+	if body.Type != "" && body.Type != "about:blank" {
+		problem.Type = body.Type
+	}
+	if body.Title != "" {
+		problem.Title = body.Title
+	}
+
+	var detail string
+	var validationErrors []apiValidationError
+	if err := json.Unmarshal(body.Detail, &detail); err == nil {
+		problem.Detail = detail
+		if m := problemTrailingField.FindStringSubmatch(detail); m != nil {
+			problem.Field = m[1]
+		} else if m := problemFieldDict.FindStringSubmatch(detail); m != nil {
+			problem.Field = m[1]
+		}
+	} else if err := json.Unmarshal(body.Detail, &validationErrors); err == nil && len(validationErrors) > 0 {
+		messages := make([]string, 0, len(validationErrors))
+		for _, v := range validationErrors {
+			field := validationErrorField(v.Loc)
+			if field != "" {
+				messages = append(messages, fmt.Sprintf("%s: %s", field, v.Msg))
+			} else {
+				messages = append(messages, v.Msg)
+			}
+			if problem.Field == "" {
+				problem.Field = field
+			}
+		}
+		problem.Detail = strings.Join(messages, "\n")
+	}
+
+	return problem
+}
+
+// validationErrorField returns the top level body field of a FastAPI
+// validation error location, such as ["body", "slots"].
+func validationErrorField(loc []interface{}) string {
+	if len(loc) < 2 || loc[0] != "body" {
+		return ""
+	}
+
+	field, _ := loc[1].(string)
+	return field
+}
+
+func (p *apiProblem) hint() string {
+	switch p.Status {
+	case http.StatusUnauthorized:
+		return "Airflow rejected the provider credentials. Check that the token has not expired and that the username and password, or the configured identity provider, are correct."
+	case http.StatusForbidden:
+		return "The provider credentials are valid but lack the permission for this operation. Check the roles granted to the user or service account in Airflow."
+	case http.StatusConflict:
+		return "The object already exists in Airflow. Import it into the Terraform state with `terraform import` to manage it."
+	}
+
+	return ""
+}
+
+// apiErrorf turns an error of the generated client into a diagnostic that
+// carries the problem detail sent by Airflow and, for validation errors of
+// a configured attribute, its path. Summary is formatted like diag.Errorf and
+// followed by the error.
+func apiErrorf(d *schema.ResourceData, err error, format string, a ...interface{}) diag.Diagnostics {
+	summary := fmt.Sprintf(format, a...)
+
+	problem := parseApiProblem(err)
+	if problem == nil {
+		return diag.Errorf("%s: %s", summary, err)
+	}
+
+	var details []string
+	if problem.Detail != "" {
+		details = append(details, problem.Detail)
+	}
+	if hint := problem.hint(); hint != "" {
+		details = append(details, hint)
+	}
+	status := fmt.Sprintf("HTTP status: %d %s", problem.Status, http.StatusText(problem.Status))
+	if problem.Type != "" {
+		status += fmt.Sprintf(" (%s)", problem.Type)
+	}
+	details = append(details, status)
+
+	diagnostic := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("%s: %s", summary, problem.Title),
+		Detail:   strings.Join(details, "\n\n"),
+	}
+	if problem.Field != "" && d != nil && hasConfigAttribute(d, problem.Field) {
+		diagnostic.AttributePath = cty.GetAttrPath(problem.Field)
+	}
+
+	return diag.Diagnostics{diagnostic}
+}
+
+func hasConfigAttribute(d *schema.ResourceData, name string) bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.Type().IsObjectType() {
+		return false
+	}
+
+	return config.Type().HasAttribute(name)
+}
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestApiErrorf(t *testing.T) {
+	cases := []struct {
+		name    string
+		status  int
+		body    string
+		summary string
+		detail  string
+	}{
+		{
+			name:    "problem detail",
+			status:  http.StatusBadRequest,
+			body:    `{"detail": "'abc' is not of type 'string' - 'value'", "status": 400, "title": "Bad Request", "type": "https://airflow.apache.org/docs/apache-airflow/stable/stable-rest-api-ref.html#section/Errors/BadRequest"}`,
+			summary: "failed to create variable `foo` from Airflow: Bad Request",
+			detail:  "'abc' is not of type 'string' - 'value'",
+		},
+		{
+			name:    "marshmallow validation",
+			status:  http.StatusBadRequest,
+			body:    `{"detail": "{'key': ['Missing data for required field.']}", "status": 400, "title": "Bad Request", "type": "about:blank"}`,
+			summary: "failed to create variable `foo` from Airflow: Bad Request",
+			detail:  "Missing data for required field.",
+		},
+		{
+			name:    "fastapi validation",
+			status:  http.StatusUnprocessableEntity,
+			body:    `{"detail": [{"loc": ["body", "value"], "msg": "Field required", "type": "missing"}]}`,
+			summary: "failed to create variable `foo` from Airflow: Unprocessable Entity",
+			detail:  "value: Field required",
+		},
+		{
+			name:    "unknown field",
+			status:  http.StatusBadRequest,
+			body:    `{"detail": "Invalid input - 'conn_type'"}`,
+			summary: "failed to create variable `foo` from Airflow: Bad Request",
+			detail:  "Invalid input",
+		},
+		{
+			name:    "unauthorized",
+			status:  http.StatusUnauthorized,
+			body:    `{"detail": "Invalid JWT token", "status": 401, "title": "Unauthorized", "type": "about:blank"}`,
+			summary: "failed to create variable `foo` from Airflow: Unauthorized",
+			detail:  "rejected the provider credentials",
+		},
+		{
+			name:    "forbidden",
+			status:  http.StatusForbidden,
+			body:    `{"detail": null, "status": 403, "title": "Forbidden", "type": "about:blank"}`,
+			summary: "failed to create variable `foo` from Airflow: Forbidden",
+			detail:  "lack the permission",
+		},
+		{
+			name:    "no body",
+			status:  http.StatusBadGateway,
+			body:    `<html>Bad Gateway</html>`,
+			summary: "failed to create variable `foo` from Airflow: Bad Gateway",
+			detail:  "HTTP status: 502 Bad Gateway",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/problem+json")
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.body)
+			}))
+			defer server.Close()
+
+			u, _ := url.Parse(server.URL)
+			client := newApiClient(u, apiServerPath("", apiVersionV1), server.Client(), false)
+			_, _, err := client.VariableApi.GetVariable(context.Background(), "foo").Execute()
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			d := resourcePool().Data(&terraform.InstanceState{})
+			d.SetId("foo")
+			diags := apiErrorf(d, err, "failed to create variable `%s` from Airflow", "foo")
+			if len(diags) != 1 {
+				t.Fatalf("expected one diagnostic, got %d", len(diags))
+			}
+			if diags[0].Summary != tc.summary {
+				t.Errorf("expected summary %q, got %q", tc.summary, diags[0].Summary)
+			}
+			if !strings.Contains(diags[0].Detail, tc.detail) {
+				t.Errorf("expected detail to contain %q, got %q", tc.detail, diags[0].Detail)
+			}
+
+			err = sendApiRequest(context.Background(), client, "VariableApiService.GetVariable", http.MethodGet, "/variables/foo", nil, nil)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if sent := apiErrorf(d, err, "failed to create variable `%s` from Airflow", "foo"); !reflect.DeepEqual(sent, diags) {
+				t.Errorf("expected the same diagnostics for a request built by hand, got %v and %v", sent, diags)
+			}
+		})
+	}
+}
+
+func TestParseApiProblemField(t *testing.T) {
+	cases := map[string]string{
+		`{"detail": "'abc' is not of type 'integer' - 'slots'"}`:                              "slots",
+		`{"detail": "{'slots': ['Not a valid integer.']}"}`:                                   "slots",
+		`{"detail": [{"loc": ["body", "slots"], "msg": "Input should be a valid integer"}]}`:  "slots",
+		`{"detail": [{"loc": ["query", "limit"], "msg": "Input should be a valid integer"}]}`: "",
+		`{"detail": "Pool not found"}`:                                                        "",
+	}
+
+	for body, expected := range cases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, body)
+		}))
+
+		u, _ := url.Parse(server.URL)
+		client := newApiClient(u, apiServerPath("", apiVersionV1), server.Client(), false)
+		_, _, err := client.PoolApi.GetPool(context.Background(), "foo").Execute()
+		server.Close()
+
+		problem := parseApiProblem(err)
+		if problem == nil {
+			t.Fatalf("expected a problem for %s", body)
+		}
+		if problem.Field != expected {
+			t.Errorf("expected field %q for %s, got %q", expected, body, problem.Field)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var _ provider.Provider = &frameworkProvider{}
+
+// frameworkProvider serves the resources migrated to terraform-plugin-framework.
+// Both providers are served together by a mux server, so it shares the schema
+// and the configuration of the SDK provider instead of configuring its own
+// API clients.
+type frameworkProvider struct {
+	sdk *schema.Provider
+}
+
+// NewFrameworkProvider returns the framework provider sharing the
+// configuration of sdk.
+func NewFrameworkProvider(sdk *schema.Provider) provider.Provider {
+	return &frameworkProvider{sdk: sdk}
+}
+
+// NewMuxServer serves the SDK provider and the framework provider as a single
+// provider. The SDK provider comes first so that it is configured before the
+// framework provider.
+func NewMuxServer(ctx context.Context, sdk *schema.Provider) (tfprotov5.ProviderServer, error) {
+	providers := []func() tfprotov5.ProviderServer{
+		func() tfprotov5.ProviderServer { return newReadOnlyProviderServer(sdk) },
+		providerserver.NewProtocol5(NewFrameworkProvider(sdk)),
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, providers...)
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer(), nil
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "airflow"
+}
+
+func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	attributes, blocks := frameworkProviderSchema(p.sdk.Schema)
+	resp.Schema = fwschema.Schema{
+		Attributes: attributes,
+		Blocks:     blocks,
+	}
+}
+
+// Configure leaves the configuration to the SDK provider, which validated it
+// and built the API clients already.
+func (p *frameworkProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	resp.ResourceData = p.sdk
+	resp.DataSourceData = p.sdk
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newVariableResource,
+	}
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		newConnectionDataSource,
+		newDagDataSource,
+		newDagsDataSource,
+		newPoolDataSource,
+		newPoolsDataSource,
+		newUnmanagedObjectsDataSource,
+		newVariableDataSource,
+		newVariablesDataSource,
+	}
+}
+
+// frameworkProviderSchema mirrors the schema of the SDK provider, which the
+// mux server requires to be identical.
+func frameworkProviderSchema(s map[string]*schema.Schema) (map[string]fwschema.Attribute, map[string]fwschema.Block) {
+	attributes := make(map[string]fwschema.Attribute)
+	blocks := make(map[string]fwschema.Block)
+
+	for name, v := range s {
+		if elem, ok := v.Elem.(*schema.Resource); ok {
+			nestedAttributes, nestedBlocks := frameworkProviderSchema(elem.Schema)
+			blocks[name] = fwschema.ListNestedBlock{
+				Description: v.Description,
+				NestedObject: fwschema.NestedBlockObject{
+					Attributes: nestedAttributes,
+					Blocks:     nestedBlocks,
+				},
+			}
+			continue
+		}
+
+		// Like the SDK, an attribute required unless set in the environment
+		// is only required while it is not.
+		required := v.Required
+		optional := v.Optional
+		if required && v.DefaultFunc != nil {
+			if dv, err := v.DefaultFunc(); err != nil || dv != nil {
+				required, optional = false, true
+			}
+		}
+
+		attributes[name] = frameworkProviderAttribute(v, required, optional)
+	}
+
+	return attributes, blocks
+}
+
+func frameworkProviderAttribute(v *schema.Schema, required, optional bool) fwschema.Attribute {
+	switch v.Type {
+	case schema.TypeBool:
+		return fwschema.BoolAttribute{Description: v.Description, Required: required, Optional: optional, Sensitive: v.Sensitive}
+	case schema.TypeInt:
+		return fwschema.Int64Attribute{Description: v.Description, Required: required, Optional: optional, Sensitive: v.Sensitive}
+	case schema.TypeFloat:
+		return fwschema.Float64Attribute{Description: v.Description, Required: required, Optional: optional, Sensitive: v.Sensitive}
+	case schema.TypeList:
+		return fwschema.ListAttribute{Description: v.Description, Required: required, Optional: optional, Sensitive: v.Sensitive, ElementType: types.StringType}
+	case schema.TypeMap:
+		return fwschema.MapAttribute{Description: v.Description, Required: required, Optional: optional, Sensitive: v.Sensitive, ElementType: types.StringType}
+	default:
+		return fwschema.StringAttribute{Description: v.Description, Required: required, Optional: optional, Sensitive: v.Sensitive}
+	}
+}
+
+// frameworkProviderConfig returns the configuration of the SDK provider, which
+// is only known once Terraform configured the provider.
+func frameworkProviderConfig(providerData any) (ProviderConfig, fwdiag.Diagnostics) {
+	var diags fwdiag.Diagnostics
+
+	sdk, ok := providerData.(*schema.Provider)
+	if !ok {
+		diags.AddError("Unconfigured provider", fmt.Sprintf("expected the provider data to be *schema.Provider, got %T", providerData))
+		return ProviderConfig{}, diags
+	}
+
+	pcfg, ok := sdk.Meta().(ProviderConfig)
+	if !ok {
+		diags.AddError("Unconfigured provider", "the provider was not configured before its resources were used")
+	}
+
+	return pcfg, diags
+}
+
+// frameworkDiags converts the diagnostics of the helpers shared with the SDK
+// resources.
+func frameworkDiags(diags diag.Diagnostics) fwdiag.Diagnostics {
+	var converted fwdiag.Diagnostics
+
+	for _, d := range diags {
+		attributePath := path.Empty()
+		for _, step := range d.AttributePath {
+			if attr, ok := step.(cty.GetAttrStep); ok {
+				attributePath = attributePath.AtName(attr.Name)
+			}
+		}
+
+		switch {
+		case d.Severity == diag.Error && len(attributePath.Steps()) > 0:
+			converted.AddAttributeError(attributePath, d.Summary, d.Detail)
+		case d.Severity == diag.Error:
+			converted.AddError(d.Summary, d.Detail)
+		case len(attributePath.Steps()) > 0:
+			converted.AddAttributeWarning(attributePath, d.Summary, d.Detail)
+		default:
+			converted.AddWarning(d.Summary, d.Detail)
+		}
+	}
+
+	return converted
+}
+
+// frameworkErrorSummary returns the summary of the first error, to mark the
+// span of a failed operation.
+func frameworkErrorSummary(diags fwdiag.Diagnostics) string {
+	errs := diags.Errors()
+	if len(errs) == 0 {
+		return ""
+	}
+
+	return errs[0].Summary()
+}
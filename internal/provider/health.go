@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const healthPollInterval = 5 * time.Second
+
+var defaultReadyComponents = []string{"metadatabase", "scheduler"}
+
+type waitForReadyConfig struct {
+	timeout    time.Duration
+	components []string
+}
+
+func expandWaitForReady(tfList []interface{}) *waitForReadyConfig {
+	if len(tfList) == 0 {
+		return nil
+	}
+
+	config := &waitForReadyConfig{
+		components: defaultReadyComponents,
+	}
+
+	tfMap, ok := tfList[0].(map[string]interface{})
+	if !ok {
+		return config
+	}
+
+	config.timeout = time.Duration(tfMap["timeout"].(int)) * time.Second
+
+	if v, ok := tfMap["components"].([]interface{}); ok && len(v) > 0 {
+		config.components = make([]string, 0, len(v))
+		for _, component := range v {
+			config.components = append(config.components, component.(string))
+		}
+	}
+
+	return config
+}
+
+// waitForReady polls the health endpoint until every required component
+// reports healthy. When apiVersion is auto the API version is detected along
+// the way, as the webserver may not even be listening yet, and returned with
+// the Airflow version.
+func waitForReady(ctx context.Context, u *url.URL, path, apiVersion string, client *http.Client, config *waitForReadyConfig) (string, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, config.timeout)
+	defer cancel()
+
+	var lastErr error
+	var serverVersion string
+	for {
+		var err error
+		if apiVersion == apiVersionAuto {
+			var detected string
+			if detected, serverVersion, err = detectApiVersion(ctx, u, path, client); err == nil {
+				apiVersion = detected
+			}
+		}
+		if err == nil {
+			err = checkHealth(ctx, u, path, apiVersion, client, config.components)
+		}
+		if err == nil {
+			return apiVersion, serverVersion, nil
+		}
+
+		// Keep the reason of the last complete check rather than the
+		// cancellation of the one cut short by the timeout.
+		if ctx.Err() == nil || lastErr == nil {
+			lastErr = err
+		}
+		log.Printf("[DEBUG] Airflow is not ready yet: %s", err)
+
+		if ctx.Err() != nil || sleepWithContext(ctx, healthPollInterval) != nil {
+			return "", "", fmt.Errorf("not ready after %s: %s", config.timeout, lastErr)
+		}
+	}
+}
+
+func checkHealth(ctx context.Context, u *url.URL, path, apiVersion string, client *http.Client, components []string) error {
+	healthPath := "/health"
+	if apiVersion == apiVersionV2 {
+		healthPath = "/monitor/health"
+	}
+	healthURL := fmt.Sprint(u.Scheme, "://", u.Host, apiServerPath(path, apiVersion), healthPath)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", healthURL, resp.Status)
+	}
+
+	var health map[string]struct {
+		Status *string `json:"status"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		return fmt.Errorf("failed to decode %s: %s", healthURL, err)
+	}
+
+	var unhealthy []string
+	for _, component := range components {
+		status := "unknown"
+		if v, ok := health[component]; ok && v.Status != nil {
+			status = *v.Status
+		}
+		if status != "healthy" {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s is %s", component, status))
+		}
+	}
+
+	if len(unhealthy) > 0 {
+		sort.Strings(unhealthy)
+		return fmt.Errorf("%s", strings.Join(unhealthy, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newHealthTestServer(schedulerStatus string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/version":
+			fmt.Fprint(w, `{"version": "3.0.2"}`)
+		case "/api/v2/monitor/health":
+			fmt.Fprintf(w, `{
+  "metadatabase": {"status": "healthy"},
+  "scheduler": {"status": %q, "latest_scheduler_heartbeat": null},
+  "triggerer": {"status": null}
+}`, schedulerStatus)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestWaitForReady(t *testing.T) {
+	server := newHealthTestServer("healthy")
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	apiVersion, _, err := waitForReady(context.Background(), u, "", apiVersionAuto, server.Client(), &waitForReadyConfig{
+		timeout:    time.Second,
+		components: defaultReadyComponents,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if apiVersion != apiVersionV2 {
+		t.Errorf("expected detected API version %s, got %s", apiVersionV2, apiVersion)
+	}
+}
+
+func TestWaitForReady_unhealthy(t *testing.T) {
+	server := newHealthTestServer("unhealthy")
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	_, _, err := waitForReady(context.Background(), u, "", apiVersionV2, server.Client(), &waitForReadyConfig{
+		timeout:    time.Second,
+		components: []string{"metadatabase", "scheduler", "triggerer"},
+	})
+	if err == nil {
+		t.Fatal("expected an error for unhealthy components")
+	}
+	if !strings.Contains(err.Error(), "scheduler is unhealthy") || !strings.Contains(err.Error(), "triggerer is unknown") {
+		t.Errorf("expected unhealthy components to be named, got: %s", err)
+	}
+	if strings.Contains(err.Error(), "metadatabase") {
+		t.Errorf("expected healthy components not to be named, got: %s", err)
+	}
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// The Airflow versions that introduced the description of each object type.
+const (
+	connectionDescriptionMinVersion = "2.3.0"
+	poolDescriptionMinVersion       = "2.3.0"
+	variableDescriptionMinVersion   = "2.4.0"
+)
+
+// managedByMarkerSeparator separates the marker from the description set in
+// the configuration.
+const managedByMarkerSeparator = "\n\n"
+
+// supportsVersion reports whether the Airflow server runs minVersion or later,
+// assuming it does when its version is unknown.
+func (p ProviderConfig) supportsVersion(minVersion string) bool {
+	if p.AirflowVersion == nil {
+		return true
+	}
+
+	return !p.AirflowVersion.Core().LessThan(version.Must(version.NewVersion(minVersion)))
+}
+
+// withManagedByMarker returns the description to store in Airflow for an
+// object created by the provider. It is left unchanged when no marker is
+// configured or Airflow has no description for the object type.
+func (p ProviderConfig) withManagedByMarker(description, minVersion string) string {
+	if p.managedByMarker == "" || !p.supportsVersion(minVersion) {
+		return description
+	}
+	if description == "" {
+		return p.managedByMarker
+	}
+
+	return description + managedByMarkerSeparator + p.managedByMarker
+}
+
+// withoutManagedByMarker returns the description stored in Airflow without the
+// marker, as set in the configuration.
+func (p ProviderConfig) withoutManagedByMarker(description string) string {
+	if p.managedByMarker == "" {
+		return description
+	}
+	if description == p.managedByMarker {
+		return ""
+	}
+
+	return strings.TrimSuffix(description, managedByMarkerSeparator+p.managedByMarker)
+}
+
+func hasManagedByMarker(description, marker string) bool {
+	return strings.HasSuffix(description, marker)
+}
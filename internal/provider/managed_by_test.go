@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestManagedByMarker(t *testing.T) {
+	cases := []struct {
+		name           string
+		marker         string
+		airflowVersion string
+		description    string
+		stored         string
+	}{
+		{
+			name:        "no marker",
+			description: "foo",
+			stored:      "foo",
+		},
+		{
+			name:   "empty description",
+			marker: "[terraform]",
+			stored: "[terraform]",
+		},
+		{
+			name:        "description",
+			marker:      "[terraform]",
+			description: "foo",
+			stored:      "foo\n\n[terraform]",
+		},
+		{
+			name:           "unsupported",
+			marker:         "[terraform]",
+			airflowVersion: "2.2.5",
+			description:    "foo",
+			stored:         "foo",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pcfg := ProviderConfig{managedByMarker: tc.marker}
+			if tc.airflowVersion != "" {
+				pcfg.AirflowVersion = version.Must(version.NewVersion(tc.airflowVersion))
+			}
+
+			stored := pcfg.withManagedByMarker(tc.description, connectionDescriptionMinVersion)
+			if stored != tc.stored {
+				t.Errorf("expected %q to be stored, got %q", tc.stored, stored)
+			}
+			if got := pcfg.withoutManagedByMarker(stored); got != tc.description {
+				t.Errorf("expected %q to be read back, got %q", tc.description, got)
+			}
+		})
+	}
+}
+
+func TestPoolManagedByMarkerOnCreateOnly(t *testing.T) {
+	descriptions := map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			body, _ := io.ReadAll(r.Body)
+			var pool map[string]interface{}
+			json.Unmarshal(body, &pool)
+			descriptions[r.Method] = pool["description"]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name": "foo", "slots": 2, "description": "set by hand"}`))
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	pcfg := ProviderConfig{
+		ApiClient:       newApiClient(u, apiServerPath("", apiVersionV1), server.Client(), false),
+		ApiVersion:      apiVersionV1,
+		managedByMarker: "managed-by: terraform",
+	}
+
+	d := resourcePool().Data(nil)
+	d.Set("name", "foo")
+	d.Set("slots", 2)
+	if diags := resourcePoolCreate(context.Background(), d, pcfg); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	d = resourcePool().Data(&terraform.InstanceState{ID: "foo"})
+	d.Set("slots", 3)
+	if diags := resourcePoolUpdate(context.Background(), d, pcfg); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := descriptions[http.MethodPost]; got != "managed-by: terraform" {
+		t.Errorf("expected the marker on create, got %v", got)
+	}
+	if got, ok := descriptions[http.MethodPatch]; !ok || got != nil {
+		t.Errorf("expected no description on update, got %v", got)
+	}
+}
+
+func TestConnectionManagedByMarkerOnUpdate(t *testing.T) {
+	cases := []struct {
+		name     string
+		current  string
+		expected string
+	}{
+		{name: "imported", current: "set by hand", expected: "updated"},
+		{name: "created by the provider", current: "[managed]", expected: "updated" + managedByMarkerSeparator + "[managed]"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var patched interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method == http.MethodPatch {
+					body, _ := io.ReadAll(r.Body)
+					var connection map[string]interface{}
+					json.Unmarshal(body, &connection)
+					patched = connection["description"]
+				}
+				json.NewEncoder(w).Encode(map[string]string{"connection_id": "foo", "conn_type": "http", "description": tc.current})
+			}))
+			defer server.Close()
+
+			u, _ := url.Parse(server.URL)
+			pcfg := ProviderConfig{
+				ApiClient:       newApiClient(u, apiServerPath("", apiVersionV1), server.Client(), false),
+				ApiVersion:      apiVersionV1,
+				managedByMarker: "[managed]",
+			}
+
+			d := resourceConnection().Data(&terraform.InstanceState{ID: "foo"})
+			d.Set("connection_id", "foo")
+			d.Set("conn_type", "http")
+			d.Set("description", "updated")
+			if diags := resourceConnectionUpdate(context.Background(), d, pcfg); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if patched != tc.expected {
+				t.Errorf("expected description %q to be sent, got %v", tc.expected, patched)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package provider
+
+// pageLimit is the default maximum page size of the Airflow REST API.
+const pageLimit int32 = 100
+
+// listAll calls listPage with the offset of each page of a collection until
+// all of its entries were listed. listPage returns the number of entries in the
+// page and the total number of entries in the collection.
+func listAll(listPage func(offset int32) (int, int32, error)) error {
+	var offset int32
+	for {
+		n, total, err := listPage(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += int32(n)
+		if n == 0 || offset >= total {
+			return nil
+		}
+	}
+}
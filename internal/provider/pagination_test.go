@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestListAll(t *testing.T) {
+	var offsets []int32
+	err := listAll(func(offset int32) (int, int32, error) {
+		offsets = append(offsets, offset)
+		if offset >= 200 {
+			return 50, 250, nil
+		}
+		return int(pageLimit), 250, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(offsets) != 3 || offsets[1] != 100 || offsets[2] != 200 {
+		t.Errorf("unexpected pages requested at %v", offsets)
+	}
+}
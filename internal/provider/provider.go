@@ -7,17 +7,42 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/apache/airflow-client-go/airflow"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 type ProviderConfig struct {
-	ApiClient   *airflow.APIClient
-	AuthContext context.Context
+	ApiClient       *airflow.APIClient
+	FabApiClient    *airflow.APIClient
+	ApiVersion      string
+	AirflowVersion  *version.Version
+	readOnly        bool
+	managedByMarker string
+	accessToken     string
+	basicAuth       *airflow.BasicAuth
+
+	tracerProvider *sdktrace.TracerProvider
+}
+
+// AuthContext attaches the provider credentials to ctx, the per-operation
+// context Terraform hands to a CRUD function, so that its cancellation and
+// deadline reach the API requests made with it.
+func (p ProviderConfig) AuthContext(ctx context.Context) context.Context {
+	if p.accessToken != "" {
+		ctx = context.WithValue(ctx, airflow.ContextAccessToken, p.accessToken)
+	}
+
+	if p.basicAuth != nil {
+		ctx = context.WithValue(ctx, airflow.ContextBasicAuth, *p.basicAuth)
+	}
+
+	return ctx
 }
 
 func AirflowProvider() *schema.Provider {
@@ -35,7 +60,14 @@ func AirflowProvider() *schema.Provider {
 				Sensitive:     true,
 				Description:   "The oauth to use for API authentication",
 				DefaultFunc:   schema.EnvDefaultFunc("AIRFLOW_OAUTH2_TOKEN", nil),
-				ConflictsWith: []string{"username", "password"},
+				ConflictsWith: []string{"oauth2_token_file", "username", "password", "mwaa", "google_id_token", "oauth2_client_credentials", "exec"},
+			},
+			"oauth2_token_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The path to a file holding the token to use for API authentication, read again when it changes",
+				DefaultFunc:   schema.EnvDefaultFunc("AIRFLOW_OAUTH2_TOKEN_FILE", nil),
+				ConflictsWith: []string{"oauth2_token", "username", "password", "mwaa", "google_id_token", "oauth2_client_credentials", "exec"},
 			},
 			"username": {
 				Type:          schema.TypeString,
@@ -43,7 +75,7 @@ func AirflowProvider() *schema.Provider {
 				Optional:      true,
 				Description:   "The username to use for API basic authentication",
 				RequiredWith:  []string{"password"},
-				ConflictsWith: []string{"oauth2_token"},
+				ConflictsWith: []string{"oauth2_token", "oauth2_token_file", "mwaa", "google_id_token", "oauth2_client_credentials", "exec"},
 			},
 			"password": {
 				Type:          schema.TypeString,
@@ -52,14 +84,295 @@ func AirflowProvider() *schema.Provider {
 				Sensitive:     true,
 				Description:   "The password to use for API basic authentication",
 				RequiredWith:  []string{"username"},
-				ConflictsWith: []string{"oauth2_token"},
+				ConflictsWith: []string{"oauth2_token", "oauth2_token_file", "mwaa", "google_id_token", "oauth2_client_credentials", "exec"},
+			},
+			"mwaa": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				Description:   "Authenticate against an Amazon MWAA environment with the standard AWS credential chain",
+				ConflictsWith: []string{"oauth2_token", "oauth2_token_file", "username", "password", "google_id_token", "oauth2_client_credentials", "exec"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"environment_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the MWAA environment",
+						},
+						"region": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The AWS region of the MWAA environment, defaults to the region of the AWS configuration",
+						},
+						"endpoint": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "A custom endpoint for the MWAA control plane API",
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						},
+					},
+				},
+			},
+			"google_id_token": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				Description:   "Authenticate with Google-signed OIDC ID tokens, as required by Cloud Composer and IAP",
+				ConflictsWith: []string{"oauth2_token", "oauth2_token_file", "username", "password", "mwaa", "oauth2_client_credentials", "exec"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"target_audience": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The audience of the ID token, the webserver URL or the IAP OAuth client ID",
+						},
+						"credentials_file": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							Description:   "The path to a service account key file, defaults to application default credentials",
+							ConflictsWith: []string{"google_id_token.0.credentials_json"},
+						},
+						"credentials_json": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							Sensitive:     true,
+							Description:   "The contents of a service account key file, defaults to application default credentials",
+							ConflictsWith: []string{"google_id_token.0.credentials_file"},
+						},
+						"token_endpoint": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The endpoint service account assertions are exchanged at, defaults to the token_uri of the credentials",
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						},
+					},
+				},
+			},
+			"oauth2_client_credentials": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				Description:   "Fetch access tokens from an identity provider with the OAuth2 client credentials grant",
+				ConflictsWith: []string{"oauth2_token", "oauth2_token_file", "username", "password", "mwaa", "google_id_token", "exec"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"token_url": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The token endpoint of the identity provider",
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						},
+						"client_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The OAuth2 client ID",
+						},
+						"client_secret": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "The OAuth2 client secret",
+						},
+						"scopes": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The scopes to request",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"endpoint_params": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "Additional parameters sent to the token endpoint, such as audience",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"exec": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				Description:   "Run a credential helper that prints a token with its expiry as JSON, and run it again once the token expires",
+				ConflictsWith: []string{"oauth2_token", "oauth2_token_file", "username", "password", "mwaa", "google_id_token", "oauth2_client_credentials"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"command": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The command to run",
+						},
+						"args": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The arguments to pass to the command",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"env": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "Additional environment variables to set for the command",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"api_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The Airflow REST API version to use, one of `auto`, `v1` or `v2`",
+				DefaultFunc:  schema.EnvDefaultFunc("AIRFLOW_API_VERSION", apiVersionAuto),
+				ValidateFunc: validation.StringInSlice([]string{apiVersionAuto, apiVersionV1, apiVersionV2}, false),
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether to refuse any change to Airflow, for drift detection with credentials that must not mutate anything",
+				DefaultFunc: schema.EnvDefaultFunc("AIRFLOW_READ_ONLY", false),
+			},
+			"managed_by_marker": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A marker written into the description of the connections, pools and variables created by the provider, to detect the objects created outside of Terraform",
+				DefaultFunc: schema.EnvDefaultFunc("AIRFLOW_MANAGED_BY_MARKER", nil),
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The maximum number of times a failed API request is retried",
+				DefaultFunc:  schema.EnvDefaultFunc("AIRFLOW_MAX_RETRIES", 4),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_min_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The minimum time in seconds to wait before retrying a failed API request",
+				DefaultFunc:  schema.EnvDefaultFunc("AIRFLOW_RETRY_MIN_WAIT", 1),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The maximum time in seconds to wait before retrying a failed API request",
+				DefaultFunc:  schema.EnvDefaultFunc("AIRFLOW_RETRY_MAX_WAIT", 30),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The maximum number of API requests in flight at once, 0 for no limit",
+				DefaultFunc:  schema.EnvDefaultFunc("AIRFLOW_MAX_CONCURRENT_REQUESTS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Description:  "The maximum number of API requests sent per second, 0 for no limit",
+				DefaultFunc:  schema.EnvDefaultFunc("AIRFLOW_REQUESTS_PER_SECOND", 0.0),
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The path to a PEM-encoded CA bundle used to verify the Airflow server certificate",
+				DefaultFunc:   schema.EnvDefaultFunc("AIRFLOW_CA_CERT_FILE", nil),
+				ConflictsWith: []string{"ca_cert_pem"},
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "A PEM-encoded CA bundle used to verify the Airflow server certificate",
+				DefaultFunc:   schema.EnvDefaultFunc("AIRFLOW_CA_CERT_PEM", nil),
+				ConflictsWith: []string{"ca_cert_file"},
+			},
+			"client_cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "A PEM-encoded client certificate, or the path to one, used for mutual TLS",
+				DefaultFunc:  schema.EnvDefaultFunc("AIRFLOW_CLIENT_CERT", nil),
+				RequiredWith: []string{"client_key"},
+			},
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "A PEM-encoded client private key, or the path to one, used for mutual TLS",
+				DefaultFunc:  schema.EnvDefaultFunc("AIRFLOW_CLIENT_KEY", nil),
+				RequiredWith: []string{"client_cert"},
+			},
+			"tls_server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The server name used to verify the Airflow server certificate, if it differs from the base_endpoint host",
+				DefaultFunc: schema.EnvDefaultFunc("AIRFLOW_TLS_SERVER_NAME", nil),
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether to skip verification of the Airflow server certificate",
+				DefaultFunc: schema.EnvDefaultFunc("AIRFLOW_INSECURE_SKIP_VERIFY", false),
+			},
+			"headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Additional HTTP headers to send with every API request",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The URL of the HTTP proxy to send API requests through",
+				DefaultFunc:  schema.EnvDefaultFunc("AIRFLOW_PROXY_URL", nil),
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+			},
+			"no_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A comma-separated list of hosts that are reached without the proxy",
+				DefaultFunc: schema.EnvDefaultFunc("AIRFLOW_NO_PROXY", nil),
+			},
+			"log_redact_fields": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Additional JSON fields, form fields and headers to mask in debug logs of API requests and responses",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"client_debug": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether the API client dumps requests and responses to the log without masking secrets, for troubleshooting only",
+				DefaultFunc: schema.EnvDefaultFunc("AIRFLOW_CLIENT_DEBUG", false),
+			},
+			"wait_for_ready": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Wait for Airflow to report healthy before the first operation",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"timeout": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      300,
+							Description:  "The time in seconds to wait for Airflow to become ready",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"components": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The components that must report healthy, defaults to metadatabase and scheduler",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{"metadatabase", "scheduler", "triggerer", "dag_processor"}, false),
+							},
+						},
+					},
+				},
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"airflow_connection": resourceConnection(),
 			"airflow_dag":        resourceDag(),
 			"airflow_dag_run":    resourceDagRun(),
-			"airflow_variable":   resourceVariable(),
 			"airflow_pool":       resourcePool(),
 			"airflow_role":       resourceRole(),
 			"airflow_user":       resourceUser(),
@@ -71,24 +384,104 @@ func AirflowProvider() *schema.Provider {
 		return providerConfigure(ctx, d)
 	}
 
+	for name, r := range provider.ResourcesMap {
+		traceOperations(name, r)
+	}
+
 	return provider
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	transport := logging.NewLoggingHTTPTransport(http.DefaultTransport)
+	minWait := time.Duration(d.Get("retry_min_wait").(int)) * time.Second
+	maxWait := time.Duration(d.Get("retry_max_wait").(int)) * time.Second
+	if maxWait < minWait {
+		return nil, diag.Errorf("retry_max_wait (%s) must not be lower than retry_min_wait (%s)", maxWait, minWait)
+	}
+
+	tlsConfig, err := expandTLSConfig(d)
+	if err != nil {
+		return nil, diag.Errorf("invalid TLS configuration: %s", err)
+	}
+	if tlsConfig.InsecureSkipVerify {
+		log.Printf("[WARN] Airflow server certificate verification is disabled")
+	}
+
+	baseTransport := http.DefaultTransport.(*http.Transport).Clone()
+	baseTransport.TLSClientConfig = tlsConfig
+	baseTransport.Proxy = expandProxyFunc(d)
+
+	var redactFields []string
+	for _, v := range d.Get("log_redact_fields").([]interface{}) {
+		redactFields = append(redactFields, v.(string))
+	}
+
+	// The headers often carry API gateway keys, their values are masked in
+	// the debug logs along with the auth headers.
+	headers := make(map[string]string)
+	var redactHeaders []string
+	for k, v := range d.Get("headers").(map[string]interface{}) {
+		headers[k] = v.(string)
+		redactHeaders = append(redactHeaders, k)
+	}
+
+	// Requests to identity providers outside of Airflow share the proxy and
+	// TLS settings but not the headers meant for Airflow.
+	externalTransport := http.DefaultTransport.(*http.Transport).Clone()
+	externalTransport.TLSClientConfig = externalTLSConfig(tlsConfig)
+	externalTransport.Proxy = baseTransport.Proxy
+	externalClient := &http.Client{
+		Transport: newRedactingLoggingTransport(externalTransport, redactFields, nil),
+	}
+
+	// The limiter sits below the retries so that every attempt is throttled.
+	limitedTransport := newLimitTransport(
+		newRedactingLoggingTransport(baseTransport, redactFields, redactHeaders),
+		d.Get("max_concurrent_requests").(int),
+		d.Get("requests_per_second").(float64),
+	)
+
+	var transport http.RoundTripper = &retryTransport{
+		base:       limitedTransport,
+		maxRetries: d.Get("max_retries").(int),
+		minWait:    minWait,
+		maxWait:    maxWait,
+	}
+
+	if len(headers) > 0 {
+		transport = &headerTransport{
+			base:    transport,
+			headers: headers,
+		}
+	}
+
+	tracerProvider, err := newTracerProvider(ctx)
+	if err != nil {
+		return nil, diag.Errorf("failed to set up OpenTelemetry tracing: %s", err)
+	}
+	if tracerProvider != nil {
+		log.Printf("[DEBUG] Exporting OpenTelemetry traces")
+		transport = newTracingTransport(transport, tracerProvider)
+		externalClient.Transport = newTracingTransport(externalClient.Transport, tracerProvider)
+	}
+
 	client := &http.Client{
 		Transport: transport,
 	}
 
-	ctx = context.Background()
 	endpoint := d.Get("base_endpoint").(string)
 	u, err := url.Parse(endpoint)
 	if err != nil {
 		return nil, diag.Errorf("invalid base_endpoint: %s", err)
 	}
 
+	prov := ProviderConfig{
+		readOnly:        d.Get("read_only").(bool),
+		managedByMarker: d.Get("managed_by_marker").(string),
+		tracerProvider:  tracerProvider,
+	}
+
 	if v, ok := d.GetOk("oauth2_token"); ok {
-		ctx = context.WithValue(ctx, airflow.ContextAccessToken, v)
+		prov.accessToken = v.(string)
 	}
 
 	if username, ok := d.GetOk("username"); ok {
@@ -98,32 +491,145 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		}
 		log.Printf("[DEBUG] Using API Basic Auth")
 
-		cred := airflow.BasicAuth{
+		prov.basicAuth = &airflow.BasicAuth{
 			UserName: username.(string),
 			Password: password.(string),
 		}
-		ctx = context.WithValue(ctx, airflow.ContextBasicAuth, cred)
 	}
 
 	path := strings.TrimRight(u.Path, "/")
 
-	clientConf := &airflow.Configuration{
+	if v, ok := d.GetOk("mwaa"); ok {
+		log.Printf("[DEBUG] Using MWAA Auth")
+
+		source, err := newMwaaSessionSource(ctx, v.([]interface{}), fmt.Sprint(u.Scheme, "://", u.Host, path, "/aws_mwaa/login"), &http.Client{Transport: transport})
+		if err != nil {
+			return nil, diag.Errorf("failed to configure MWAA authentication: %s", err)
+		}
+
+		client.Transport = &tokenTransport{
+			source:    newCachingTokenSource(source),
+			base:      transport,
+			authorize: setMwaaSessionCookie,
+		}
+	}
+
+	if v, ok := d.GetOk("google_id_token"); ok {
+		log.Printf("[DEBUG] Using Google ID Token Auth")
+
+		source, err := newGoogleIdTokenSource(ctx, v.([]interface{}), externalClient)
+		if err != nil {
+			return nil, diag.Errorf("failed to configure Google ID token authentication: %s", err)
+		}
+
+		client.Transport = &tokenTransport{
+			source: newCachingTokenSource(source),
+			base:   transport,
+		}
+	}
+
+	if v, ok := d.GetOk("oauth2_client_credentials"); ok {
+		log.Printf("[DEBUG] Using OAuth2 Client Credentials Auth")
+
+		client.Transport = &tokenTransport{
+			source: newCachingTokenSource(newClientCredentialsTokenSource(v.([]interface{}), externalClient)),
+			base:   transport,
+		}
+	}
+
+	if v, ok := d.GetOk("oauth2_token_file"); ok {
+		log.Printf("[DEBUG] Using OAuth2 Token File Auth")
+
+		client.Transport = &tokenTransport{
+			source: newCachingTokenSource(&fileTokenSource{path: v.(string)}),
+			base:   transport,
+		}
+	}
+
+	if v, ok := d.GetOk("exec"); ok {
+		log.Printf("[DEBUG] Using Exec Credential Auth")
+
+		client.Transport = &tokenTransport{
+			source: newCachingTokenSource(newExecTokenSource(v.([]interface{}))),
+			base:   transport,
+		}
+	}
+
+	var serverVersion string
+	apiVersion := d.Get("api_version").(string)
+	if config := expandWaitForReady(d.Get("wait_for_ready").([]interface{})); config != nil {
+		apiVersion, serverVersion, err = waitForReady(prov.AuthContext(ctx), u, path, apiVersion, client, config)
+		if err != nil {
+			return nil, diag.Diagnostics{
+				{
+					Severity: diag.Error,
+					Summary:  "Airflow is not ready",
+					Detail:   err.Error(),
+				},
+			}
+		}
+	}
+
+	if apiVersion == apiVersionAuto {
+		apiVersion, serverVersion, err = detectApiVersion(prov.AuthContext(ctx), u, path, client)
+		if err != nil {
+			return nil, diag.Errorf("failed to detect Airflow API version: %s", err)
+		}
+		log.Printf("[DEBUG] Detected Airflow API %s", apiVersion)
+	}
+
+	if cred := prov.basicAuth; cred != nil && apiVersion == apiVersionV2 {
+		log.Printf("[DEBUG] Using API JWT Auth")
+
+		client.Transport = &tokenTransport{
+			source: newCachingTokenSource(&jwtTokenSource{
+				client:   &http.Client{Transport: transport},
+				tokenURL: fmt.Sprint(u.Scheme, "://", u.Host, path, "/auth/token"),
+				username: cred.UserName,
+				password: cred.Password,
+			}),
+			base: transport,
+		}
+		// Airflow 3 rejects basic auth, the token transport authenticates instead.
+		prov.basicAuth = nil
+	}
+
+	clientDebug := d.Get("client_debug").(bool)
+	if clientDebug {
+		log.Printf("[WARN] API client debug output is enabled, secrets are logged in plaintext")
+	}
+
+	prov.ApiClient = newApiClient(u, apiServerPath(path, apiVersion), client, clientDebug)
+	prov.FabApiClient = newApiClient(u, fabApiServerPath(path, apiVersion), client, clientDebug)
+	prov.ApiVersion = apiVersion
+
+	if serverVersion == "" {
+		version, _, err := prov.ApiClient.MonitoringApi.GetVersion(prov.AuthContext(ctx)).Execute()
+		if err != nil {
+			return nil, diag.Errorf("failed to get version from Airflow: %s", err)
+		}
+		serverVersion = version.GetVersion()
+	}
+	prov.AirflowVersion, err = parseAirflowVersion(serverVersion)
+	if err != nil {
+		return nil, diag.Errorf("failed to parse Airflow version `%s`: %s", serverVersion, err)
+	}
+	log.Printf("[DEBUG] Connected to Airflow %s", prov.AirflowVersion)
+
+	return prov, diag.Diagnostics{}
+}
+
+func newApiClient(u *url.URL, serverPath string, client *http.Client, debug bool) *airflow.APIClient {
+	return airflow.NewAPIClient(&airflow.Configuration{
 		Scheme:     u.Scheme,
 		Host:       u.Host,
-		Debug:      true,
+		Debug:      debug,
 		HTTPClient: client,
 		Servers: airflow.ServerConfigurations{
 			{
-				URL:         fmt.Sprint(path, "/api/v1"),
+				URL:         serverPath,
 				Description: "Apache Airflow Stable API.",
 			},
 		},
-	}
-
-	prov := ProviderConfig{
-		ApiClient:   airflow.NewAPIClient(clientConf),
-		AuthContext: ctx,
-	}
-
-	return prov, diag.Diagnostics{}
+	})
 }
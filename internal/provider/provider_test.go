@@ -1,20 +1,31 @@
 package provider
 
 import (
+	"context"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 var testAccProviders map[string]*schema.Provider
 var testAccProvider *schema.Provider
 
+// testAccProtoV5ProviderFactories serves testAccProvider through the mux
+// server, for the resources migrated to the framework provider.
+var testAccProtoV5ProviderFactories map[string]func() (tfprotov5.ProviderServer, error)
+
 func init() {
 	testAccProvider = AirflowProvider()
 	testAccProviders = map[string]*schema.Provider{
 		"airflow": testAccProvider,
 	}
+	testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+		"airflow": func() (tfprotov5.ProviderServer, error) {
+			return NewMuxServer(context.Background(), testAccProvider)
+		},
+	}
 }
 
 func TestProvider(t *testing.T) {
@@ -23,6 +34,38 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestMuxServer(t *testing.T) {
+	for _, env := range []map[string]string{
+		{"AIRFLOW_BASE_ENDPOINT": ""},
+		{"AIRFLOW_BASE_ENDPOINT": "http://localhost:8080"},
+	} {
+		for k, v := range env {
+			t.Setenv(k, v)
+		}
+
+		server, err := NewMuxServer(context.Background(), AirflowProvider())
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		// The mux server fails when the schemas of its providers differ.
+		resp, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		for _, d := range resp.Diagnostics {
+			if d.Severity == tfprotov5.DiagnosticSeverityError {
+				t.Errorf("unexpected error: %s: %s", d.Summary, d.Detail)
+			}
+		}
+		for _, name := range []string{"airflow_connection", "airflow_variable"} {
+			if _, ok := resp.ResourceSchemas[name]; !ok {
+				t.Errorf("expected resource %s to be served", name)
+			}
+		}
+	}
+}
+
 func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = AirflowProvider()
 }
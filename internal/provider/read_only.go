@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// errReadOnly is returned by the Create, Update and Delete functions of every
+// resource, a safety net behind readOnlyCustomizeDiff.
+var errReadOnly = errors.New("the provider is read only")
+
+// readOnlyCustomizeDiff fails plans that would create or update objects while
+// the provider is read only. The SDK does not call it for destroys, which
+// readOnlyProviderServer refuses instead.
+func readOnlyCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	pcfg, ok := m.(ProviderConfig)
+	if !ok || !pcfg.readOnly {
+		return nil
+	}
+
+	if d.Id() == "" {
+		return fmt.Errorf("cannot create this object in Airflow: %s", errReadOnly)
+	}
+
+	attributes := make(map[string]bool)
+	for _, key := range d.GetChangedKeysPrefix("") {
+		attributes[strings.SplitN(key, ".", 2)[0]] = true
+	}
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	changed := make([]string, 0, len(attributes))
+	for k := range attributes {
+		changed = append(changed, k)
+	}
+	sort.Strings(changed)
+
+	return fmt.Errorf("cannot change %s of `%s` in Airflow: %s", strings.Join(changed, ", "), d.Id(), errReadOnly)
+}
+
+// readOnlyProviderServer fails the plans of the SDK provider that would
+// destroy objects while the provider is read only. The SDK plans destroys
+// without calling CustomizeDiff.
+type readOnlyProviderServer struct {
+	tfprotov5.ProviderServer
+	sdk *schema.Provider
+}
+
+func newReadOnlyProviderServer(sdk *schema.Provider) tfprotov5.ProviderServer {
+	return &readOnlyProviderServer{
+		ProviderServer: sdk.GRPCProvider(),
+		sdk:            sdk,
+	}
+}
+
+func (s *readOnlyProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	pcfg, ok := s.sdk.Meta().(ProviderConfig)
+	if !ok || !pcfg.readOnly || !isNullDynamicValue(req.ProposedNewState) || isNullDynamicValue(req.PriorState) {
+		return s.ProviderServer.PlanResourceChange(ctx, req)
+	}
+
+	summary := fmt.Sprintf("cannot delete this object in Airflow: %s", errReadOnly)
+	if id := s.priorStateId(req); id != "" {
+		summary = fmt.Sprintf("cannot delete `%s` in Airflow: %s", id, errReadOnly)
+	}
+
+	return &tfprotov5.PlanResourceChangeResponse{
+		Diagnostics: []*tfprotov5.Diagnostic{
+			{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  summary,
+			},
+		},
+	}, nil
+}
+
+func (s *readOnlyProviderServer) priorStateId(req *tfprotov5.PlanResourceChangeRequest) string {
+	r, ok := s.sdk.ResourcesMap[req.TypeName]
+	if !ok || req.PriorState == nil {
+		return ""
+	}
+
+	state, err := msgpack.Unmarshal(req.PriorState.MsgPack, r.CoreConfigSchema().ImpliedType())
+	if err != nil || state.IsNull() || !state.Type().HasAttribute("id") {
+		return ""
+	}
+	id := state.GetAttr("id")
+	if id.IsNull() || !id.IsKnown() {
+		return ""
+	}
+
+	return id.AsString()
+}
+
+func isNullDynamicValue(v *tfprotov5.DynamicValue) bool {
+	if v == nil {
+		return true
+	}
+	null, err := v.IsNull()
+
+	return err == nil && null
+}
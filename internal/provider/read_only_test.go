@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestReadOnlyCustomizeDiff(t *testing.T) {
+	cases := []struct {
+		name     string
+		state    *terraform.InstanceState
+		readOnly bool
+		err      string
+	}{
+		{
+			name:     "create",
+			readOnly: true,
+			err:      "cannot create this object in Airflow: the provider is read only",
+		},
+		{
+			name:     "update",
+			state:    &terraform.InstanceState{ID: "foo", Attributes: map[string]string{"id": "foo", "name": "foo", "slots": "1"}},
+			readOnly: true,
+			err:      "cannot change slots of `foo` in Airflow: the provider is read only",
+		},
+		{
+			name:     "no changes",
+			state:    &terraform.InstanceState{ID: "foo", Attributes: map[string]string{"id": "foo", "name": "foo", "slots": "2"}},
+			readOnly: true,
+		},
+		{
+			name: "writable",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"name":  "foo",
+				"slots": 2,
+			})
+
+			_, err := resourcePool().SimpleDiff(context.Background(), tc.state, config, ProviderConfig{readOnly: tc.readOnly})
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestReadOnlyGuard(t *testing.T) {
+	d := resourcePool().Data(&terraform.InstanceState{ID: "foo"})
+
+	diags := resourcePoolDelete(context.Background(), d, ProviderConfig{readOnly: true})
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, errReadOnly.Error()) {
+		t.Errorf("expected delete to be refused, got %v", diags)
+	}
+}
+
+func TestReadOnlyModifyPlan(t *testing.T) {
+	ctx := context.Background()
+	r := &variableResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	variable := func(value string) tftypes.Value {
+		attributes := make(map[string]tftypes.Value)
+		for name, typ := range objectType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(typ, nil)
+		}
+		attributes["id"] = tftypes.NewValue(tftypes.String, "foo")
+		attributes["key"] = tftypes.NewValue(tftypes.String, "foo")
+		attributes["value"] = tftypes.NewValue(tftypes.String, value)
+		return tftypes.NewValue(objectType, attributes)
+	}
+	null := tftypes.NewValue(objectType, nil)
+
+	cases := []struct {
+		name  string
+		state tftypes.Value
+		plan  tftypes.Value
+		err   string
+	}{
+		{"create", null, variable("bar"), "cannot create this object in Airflow: the provider is read only"},
+		{"update", variable("bar"), variable("baz"), "cannot change value of `foo` in Airflow: the provider is read only"},
+		{"delete", variable("bar"), null, "cannot delete `foo` in Airflow: the provider is read only"},
+		{"no changes", variable("bar"), variable("bar"), ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sdk := AirflowProvider()
+			sdk.SetMeta(ProviderConfig{readOnly: true})
+			r := &variableResource{providerData: sdk}
+
+			req := resource.ModifyPlanRequest{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tc.state},
+				Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: tc.plan},
+			}
+			resp := resource.ModifyPlanResponse{
+				Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: tc.plan},
+			}
+			r.ModifyPlan(ctx, req, &resp)
+
+			if tc.err == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected error: %v", resp.Diagnostics)
+				}
+				return
+			}
+			if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != tc.err {
+				t.Errorf("expected error %q, got %v", tc.err, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestReadOnlyProviderServerDestroy(t *testing.T) {
+	ctx := context.Background()
+	sdk := AirflowProvider()
+	ty := sdk.ResourcesMap["airflow_pool"].CoreConfigSchema().ImpliedType()
+
+	attributes := make(map[string]cty.Value)
+	for name, attrType := range ty.AttributeTypes() {
+		attributes[name] = cty.NullVal(attrType)
+	}
+	attributes["id"] = cty.StringVal("foo")
+	attributes["name"] = cty.StringVal("foo")
+	attributes["slots"] = cty.NumberIntVal(2)
+
+	prior, err := msgpack.Marshal(cty.ObjectVal(attributes), ty)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	proposed, err := msgpack.Marshal(cty.NullVal(ty), ty)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	req := &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         "airflow_pool",
+		PriorState:       &tfprotov5.DynamicValue{MsgPack: prior},
+		ProposedNewState: &tfprotov5.DynamicValue{MsgPack: proposed},
+		Config:           &tfprotov5.DynamicValue{MsgPack: proposed},
+	}
+
+	for _, readOnly := range []bool{true, false} {
+		sdk.SetMeta(ProviderConfig{readOnly: readOnly})
+
+		resp, err := newReadOnlyProviderServer(sdk).PlanResourceChange(ctx, req)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		var summaries []string
+		for _, d := range resp.Diagnostics {
+			if d.Severity == tfprotov5.DiagnosticSeverityError {
+				summaries = append(summaries, d.Summary)
+			}
+		}
+		expected := []string{}
+		if readOnly {
+			expected = []string{"cannot delete `foo` in Airflow: the provider is read only"}
+		}
+		if strings.Join(summaries, "\n") != strings.Join(expected, "\n") {
+			t.Errorf("expected errors %q when read only is %t, got %q", expected, readOnly, summaries)
+		}
+	}
+}
@@ -5,22 +5,36 @@ import (
 	"encoding/json"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/apache/airflow-client-go/airflow"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceConnection() *schema.Resource {
 	return &schema.Resource{
-		CreateWithoutTimeout: resourceConnectionCreate,
-		ReadWithoutTimeout:   resourceConnectionRead,
-		UpdateWithoutTimeout: resourceConnectionUpdate,
-		DeleteWithoutTimeout: resourceConnectionDelete,
+		CreateContext: resourceConnectionCreate,
+		ReadContext:   resourceConnectionRead,
+		UpdateContext: resourceConnectionUpdate,
+		DeleteContext: resourceConnectionDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			readOnlyCustomizeDiff,
+			requireAirflowVersion(map[string]string{
+				"description": connectionDescriptionMinVersion,
+			}),
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"connection_id": {
 				Type:     schema.TypeString,
@@ -86,6 +100,10 @@ func suppressSameJsonDiff(k, oldo, newo string, d *schema.ResourceData) bool {
 func resourceConnectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
 	client := pcfg.ApiClient
+
+	if pcfg.readOnly {
+		return diag.Errorf("failed to create connection `%s` from Airflow: %s", d.Get("connection_id"), errReadOnly)
+	}
 	connId := d.Get("connection_id").(string)
 	connType := d.Get("conn_type").(string)
 
@@ -98,8 +116,8 @@ func resourceConnectionCreate(ctx context.Context, d *schema.ResourceData, m int
 		conn.SetHost(v.(string))
 	}
 
-	if v, ok := d.GetOk("description"); ok {
-		conn.SetDescription(v.(string))
+	if v := pcfg.withManagedByMarker(d.Get("description").(string), connectionDescriptionMinVersion); v != "" {
+		conn.SetDescription(v)
 	}
 
 	if v, ok := d.GetOk("login"); ok {
@@ -122,9 +140,9 @@ func resourceConnectionCreate(ctx context.Context, d *schema.ResourceData, m int
 
 	connApi := client.ConnectionApi
 
-	_, _, err := connApi.PostConnection(pcfg.AuthContext).Connection(conn).Execute()
+	_, _, err := connApi.PostConnection(pcfg.AuthContext(ctx)).Connection(conn).Execute()
 	if err != nil {
-		return diag.Errorf("failed to create connection `%s` from Airflow: %s", connId, err)
+		return apiErrorf(d, err, "failed to create connection `%s` from Airflow", connId)
 	}
 	d.SetId(connId)
 
@@ -134,13 +152,13 @@ func resourceConnectionCreate(ctx context.Context, d *schema.ResourceData, m int
 func resourceConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
 	client := pcfg.ApiClient
-	connection, resp, err := client.ConnectionApi.GetConnection(pcfg.AuthContext, d.Id()).Execute()
+	connection, resp, err := client.ConnectionApi.GetConnection(pcfg.AuthContext(ctx), d.Id()).Execute()
 	if resp != nil && resp.StatusCode == 404 {
 		d.SetId("")
 		return nil
 	}
 	if err != nil {
-		return diag.Errorf("failed to get connection `%s` from Airflow: %s", d.Id(), err)
+		return apiErrorf(d, err, "failed to get connection `%s` from Airflow", d.Id())
 	}
 
 	d.Set("connection_id", connection.GetConnectionId())
@@ -150,7 +168,7 @@ func resourceConnectionRead(ctx context.Context, d *schema.ResourceData, m inter
 	d.Set("schema", connection.GetSchema())
 	d.Set("port", connection.GetPort())
 	d.Set("extra", connection.GetExtra())
-	d.Set("description", connection.GetDescription())
+	d.Set("description", pcfg.withoutManagedByMarker(connection.GetDescription()))
 
 	if v, ok := connection.GetPasswordOk(); ok {
 		d.Set("password", v)
@@ -164,6 +182,10 @@ func resourceConnectionRead(ctx context.Context, d *schema.ResourceData, m inter
 func resourceConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
 	client := pcfg.ApiClient
+
+	if pcfg.readOnly {
+		return diag.Errorf("failed to update connection `%s` from Airflow: %s", d.Id(), errReadOnly)
+	}
 	connId := d.Id()
 	connType := d.Get("conn_type").(string)
 
@@ -176,8 +198,20 @@ func resourceConnectionUpdate(ctx context.Context, d *schema.ResourceData, m int
 		conn.SetHost(v.(string))
 	}
 
-	if v, ok := d.GetOk("description"); ok {
-		conn.SetDescription(v.(string))
+	// The marker is only kept on connections that already carry it, an
+	// update does not mark an imported connection as created by the provider.
+	description := d.Get("description").(string)
+	if pcfg.managedByMarker != "" {
+		current, _, err := client.ConnectionApi.GetConnection(pcfg.AuthContext(ctx), connId).Execute()
+		if err != nil {
+			return apiErrorf(d, err, "failed to get connection `%s` from Airflow", connId)
+		}
+		if hasManagedByMarker(current.GetDescription(), pcfg.managedByMarker) {
+			description = pcfg.withManagedByMarker(description, connectionDescriptionMinVersion)
+		}
+	}
+	if description != "" {
+		conn.SetDescription(description)
 	}
 
 	if v, ok := d.GetOk("login"); ok {
@@ -200,9 +234,9 @@ func resourceConnectionUpdate(ctx context.Context, d *schema.ResourceData, m int
 		conn.SetExtra(v.(string))
 	}
 
-	_, _, err := client.ConnectionApi.PatchConnection(pcfg.AuthContext, connId).Connection(conn).Execute()
+	_, _, err := client.ConnectionApi.PatchConnection(pcfg.AuthContext(ctx), connId).Connection(conn).Execute()
 	if err != nil {
-		return diag.Errorf("failed to update connection `%s` from Airflow: %s", connId, err)
+		return apiErrorf(d, err, "failed to update connection `%s` from Airflow", connId)
 	}
 
 	return resourceConnectionRead(ctx, d, m)
@@ -212,9 +246,13 @@ func resourceConnectionDelete(ctx context.Context, d *schema.ResourceData, m int
 	pcfg := m.(ProviderConfig)
 	client := pcfg.ApiClient
 
-	resp, err := client.ConnectionApi.DeleteConnection(pcfg.AuthContext, d.Id()).Execute()
+	if pcfg.readOnly {
+		return diag.Errorf("failed to delete connection `%s` from Airflow: %s", d.Id(), errReadOnly)
+	}
+
+	resp, err := client.ConnectionApi.DeleteConnection(pcfg.AuthContext(ctx), d.Id()).Execute()
 	if err != nil {
-		return diag.Errorf("failed to delete connection `%s` from Airflow: %s", d.Id(), err)
+		return apiErrorf(d, err, "failed to delete connection `%s` from Airflow", d.Id())
 	}
 
 	if resp != nil && resp.StatusCode == 404 {
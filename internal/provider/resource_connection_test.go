@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -90,7 +91,7 @@ func testAccCheckAirflowConnectionCheckDestroy(s *terraform.State) error {
 			continue
 		}
 
-		conn, res, err := client.ApiClient.ConnectionApi.GetConnection(client.AuthContext, rs.Primary.ID).Execute()
+		conn, res, err := client.ApiClient.ConnectionApi.GetConnection(client.AuthContext(context.Background()), rs.Primary.ID).Execute()
 		if err == nil {
 			if *conn.ConnectionId == rs.Primary.ID {
 				return fmt.Errorf("Airflow Connection (%s) still exists.", rs.Primary.ID)
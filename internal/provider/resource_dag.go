@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/airflow-client-go/airflow"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -10,13 +11,20 @@ import (
 
 func resourceDag() *schema.Resource {
 	return &schema.Resource{
-		CreateWithoutTimeout: resourceDagUpdate,
-		ReadWithoutTimeout:   resourceDagRead,
-		UpdateWithoutTimeout: resourceDagUpdate,
-		DeleteWithoutTimeout: resourceDagDelete,
+		CreateContext: resourceDagUpdate,
+		ReadContext:   resourceDagRead,
+		UpdateContext: resourceDagUpdate,
+		DeleteContext: resourceDagDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: readOnlyCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"dag_id": {
 				Type:     schema.TypeString,
@@ -64,14 +72,18 @@ func resourceDagUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 	pcfg := m.(ProviderConfig)
 	client := pcfg.ApiClient
 
+	if pcfg.readOnly {
+		return diag.Errorf("failed to update DAG `%s` from Airflow: %s", d.Get("dag_id"), errReadOnly)
+	}
+
 	dagId := d.Get("dag_id").(string)
 	dagApi := client.DAGApi
 	dag := *airflow.NewDAG()
 	dag.SetIsPaused(d.Get("is_paused").(bool))
 
-	_, res, err := dagApi.PatchDag(pcfg.AuthContext, dagId).DAG(dag).Execute()
-	if res.StatusCode != 200 {
-		return diag.Errorf("failed to update DAG `%s` from Airflow: %s", dagId, err)
+	_, res, err := dagApi.PatchDag(pcfg.AuthContext(ctx), dagId).DAG(dag).Execute()
+	if res == nil || res.StatusCode != 200 {
+		return apiErrorf(d, err, "failed to update DAG `%s` from Airflow", dagId)
 	}
 	d.SetId(dagId)
 
@@ -82,18 +94,29 @@ func resourceDagRead(ctx context.Context, d *schema.ResourceData, m interface{})
 	pcfg := m.(ProviderConfig)
 	client := pcfg.ApiClient
 
-	DAG, resp, err := client.DAGApi.GetDag(pcfg.AuthContext, d.Id()).Execute()
+	DAG, resp, err := client.DAGApi.GetDag(pcfg.AuthContext(ctx), d.Id()).Execute()
 	if resp != nil && resp.StatusCode == 404 {
 		d.SetId("")
 		return nil
 	}
-	if resp.StatusCode != 200 {
-		return diag.Errorf("failed to get DAG `%s` from Airflow: %s", d.Id(), err)
+	if resp == nil || resp.StatusCode != 200 {
+		return apiErrorf(d, err, "failed to get DAG `%s` from Airflow", d.Id())
+	}
+
+	isActive := DAG.GetIsActive()
+	if pcfg.ApiVersion == apiVersionV2 {
+		var v2Dag struct {
+			IsStale bool `json:"is_stale"`
+		}
+		if err := decodeResponseBody(resp, &v2Dag); err != nil {
+			return diag.Errorf("failed to decode DAG `%s` from Airflow: %s", d.Id(), err)
+		}
+		isActive = !v2Dag.IsStale
 	}
 
 	d.Set("dag_id", DAG.DagId)
 	d.Set("is_paused", DAG.IsPaused.Get())
-	d.Set("is_active", DAG.IsActive.Get())
+	d.Set("is_active", isActive)
 	d.Set("is_subdag", DAG.IsSubdag)
 	d.Set("description", DAG.Description.Get())
 	d.Set("file_token", DAG.FileToken)
@@ -108,9 +131,13 @@ func resourceDagDelete(ctx context.Context, d *schema.ResourceData, m interface{
 	client := pcfg.ApiClient.DAGApi
 
 	if d.Get("delete_dag").(bool) {
-		resp, err := client.DeleteDag(pcfg.AuthContext, d.Id()).Execute()
+		if pcfg.readOnly {
+			return diag.Errorf("failed to delete DAG `%s` from Airflow: %s", d.Id(), errReadOnly)
+		}
+
+		resp, err := client.DeleteDag(pcfg.AuthContext(ctx), d.Id()).Execute()
 		if err != nil {
-			return diag.Errorf("failed to delete DAG `%s` from Airflow: %s", d.Id(), err)
+			return apiErrorf(d, err, "failed to delete DAG `%s` from Airflow", d.Id())
 		}
 
 		if resp != nil && resp.StatusCode == 404 {
@@ -14,14 +14,17 @@ import (
 
 func resourceDagRun() *schema.Resource {
 	return &schema.Resource{
-		CreateContext:        resourceDagRunCreate,
-		ReadWithoutTimeout:   resourceDagRunRead,
-		DeleteWithoutTimeout: resourceDagRunDelete,
+		CreateContext: resourceDagRunCreate,
+		ReadContext:   resourceDagRunRead,
+		DeleteContext: resourceDagRunDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: readOnlyCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
 			"dag_id": {
@@ -53,6 +56,10 @@ func resourceDagRunCreate(ctx context.Context, d *schema.ResourceData, m interfa
 	pcfg := m.(ProviderConfig)
 	client := pcfg.ApiClient.DAGRunApi
 
+	if pcfg.readOnly {
+		return diag.Errorf("failed to create Dag Run `%s` from Airflow: %s", d.Get("dag_id"), errReadOnly)
+	}
+
 	dagId := d.Get("dag_id").(string)
 	dagRun := *airflow.NewDAGRunWithDefaults()
 
@@ -64,20 +71,24 @@ func resourceDagRunCreate(ctx context.Context, d *schema.ResourceData, m interfa
 		dagRun.SetConf(v.(map[string]interface{}))
 	}
 
-	res, _, err := client.PostDagRun(pcfg.AuthContext, dagId).DAGRun(dagRun).Execute()
+	if pcfg.ApiVersion == apiVersionV2 {
+		dagRun.SetLogicalDateNil()
+	}
+
+	res, _, err := client.PostDagRun(pcfg.AuthContext(ctx), dagId).DAGRun(dagRun).Execute()
 	if err != nil {
-		return diag.Errorf("failed to create Dag Run `%s` from Airflow: %s", dagId, err)
+		return apiErrorf(d, err, "failed to create Dag Run `%s` from Airflow", dagId)
 	}
 	d.SetId(fmt.Sprintf("%s:%s", dagId, *res.DagRunId.Get()))
 
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{"queued", "running", "success"},
 		Target:  []string{"success"},
-		Refresh: resourceDagRunStateRefreshFunc(d.Id(), pcfg.AuthContext, client),
+		Refresh: resourceDagRunStateRefreshFunc(d.Id(), pcfg.AuthContext(ctx), client),
 		Timeout: d.Timeout(schema.TimeoutCreate),
 	}
 
-	_, err = stateConf.WaitForStateContext(pcfg.AuthContext)
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
 		return diag.Errorf("error waiting for Dag Run %q to finish: %s", d.Id(), err)
 	}
@@ -94,13 +105,13 @@ func resourceDagRunRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.FromErr(err)
 	}
 
-	dagRun, resp, err := client.GetDagRun(pcfg.AuthContext, dagId, dagRunId).Execute()
+	dagRun, resp, err := client.GetDagRun(pcfg.AuthContext(ctx), dagId, dagRunId).Execute()
 	if resp != nil && resp.StatusCode == 404 {
 		d.SetId("")
 		return nil
 	}
 	if err != nil {
-		return diag.Errorf("failed to get dagRunId `%s` from Airflow: %s", d.Id(), err)
+		return apiErrorf(d, err, "failed to get dagRunId `%s` from Airflow", d.Id())
 	}
 
 	d.Set("dag_id", dagRun.DagId)
@@ -115,14 +126,18 @@ func resourceDagRunDelete(ctx context.Context, d *schema.ResourceData, m interfa
 	pcfg := m.(ProviderConfig)
 	client := pcfg.ApiClient.DAGRunApi
 
+	if pcfg.readOnly {
+		return diag.Errorf("failed to delete Dag Run `%s` from Airflow: %s", d.Id(), errReadOnly)
+	}
+
 	dagId, dagRunId, err := airflowDagRunId(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	resp, err := client.DeleteDagRun(pcfg.AuthContext, dagId, dagRunId).Execute()
+	resp, err := client.DeleteDagRun(pcfg.AuthContext(ctx), dagId, dagRunId).Execute()
 	if err != nil {
-		return diag.Errorf("failed to delete dagRunId `%s` from Airflow: %s", d.Id(), err)
+		return apiErrorf(d, err, "failed to delete dagRunId `%s` from Airflow", d.Id())
 	}
 
 	if resp != nil && resp.StatusCode == 404 {
@@ -142,14 +157,14 @@ func airflowDagRunId(id string) (string, string, error) {
 	return parts[0], parts[1], nil
 }
 
-func resourceDagRunStateRefreshFunc(id string, pcfg context.Context, client *airflow.DAGRunApiService) resource.StateRefreshFunc {
+func resourceDagRunStateRefreshFunc(id string, ctx context.Context, client *airflow.DAGRunApiService) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		dagId, dagRunId, err := airflowDagRunId(id)
 		if err != nil {
 			return nil, "", err
 		}
 
-		dagRun, _, err := client.GetDagRun(pcfg, dagId, dagRunId).Execute()
+		dagRun, _, err := client.GetDagRun(ctx, dagId, dagRunId).Execute()
 		if err != nil {
 			return nil, "", fmt.Errorf("failed to get Dag Run `%s` from Airflow: %s", dagRunId, err)
 		}
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -104,7 +105,7 @@ func testAccCheckAirflowDagRunCheckDestroy(s *terraform.State) error {
 			return err
 		}
 
-		dagRun, res, err := client.ApiClient.DAGRunApi.GetDagRun(client.AuthContext, dagId, dagRunId).Execute()
+		dagRun, res, err := client.ApiClient.DAGRunApi.GetDagRun(client.AuthContext(context.Background()), dagId, dagRunId).Execute()
 		if err == nil {
 			if *dagRun.DagRunId.Get() == dagRunId {
 				return fmt.Errorf("Airflow DagRun (%s) still exists.", rs.Primary.ID)
@@ -1,8 +1,13 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -54,6 +59,27 @@ func TestAccAirflowDag_basic(t *testing.T) {
 	})
 }
 
+func TestDagCancelledRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	pcfg := ProviderConfig{
+		ApiClient: newApiClient(u, apiServerPath("", apiVersionV1), server.Client(), false),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	d := resourceDag().Data(&terraform.InstanceState{ID: "example", Attributes: map[string]string{"dag_id": "example"}})
+	if diags := resourceDagRead(ctx, d, pcfg); !diags.HasError() || !strings.Contains(diags[0].Summary, "failed to get DAG `example`") {
+		t.Errorf("expected read to fail with a diagnostic, got %v", diags)
+	}
+	if diags := resourceDagUpdate(ctx, d, pcfg); !diags.HasError() || !strings.Contains(diags[0].Summary, "failed to update DAG `example`") {
+		t.Errorf("expected update to fail with a diagnostic, got %v", diags)
+	}
+}
+
 func testAccCheckAirflowDagCheckDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(ProviderConfig)
 
@@ -62,7 +88,7 @@ func testAccCheckAirflowDagCheckDestroy(s *terraform.State) error {
 			continue
 		}
 
-		dag, res, err := client.ApiClient.DAGApi.GetDag(client.AuthContext, rs.Primary.ID).Execute()
+		dag, res, err := client.ApiClient.DAGApi.GetDag(client.AuthContext(context.Background()), rs.Primary.ID).Execute()
 		if err == nil {
 			deleteDag, _ := strconv.ParseBool(rs.Primary.Attributes["delete_dag"])
 
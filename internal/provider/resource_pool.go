@@ -2,21 +2,37 @@ package provider
 
 import (
 	"context"
+	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/apache/airflow-client-go/airflow"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourcePool() *schema.Resource {
 	return &schema.Resource{
-		CreateWithoutTimeout: resourcePoolCreate,
-		ReadWithoutTimeout:   resourcePoolRead,
-		UpdateWithoutTimeout: resourcePoolUpdate,
-		DeleteWithoutTimeout: resourcePoolDelete,
+		CreateContext: resourcePoolCreate,
+		ReadContext:   resourcePoolRead,
+		UpdateContext: resourcePoolUpdate,
+		DeleteContext: resourcePoolDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			readOnlyCustomizeDiff,
+			requireAirflowVersion(map[string]string{
+				"include_deferred": "2.7.0",
+			}),
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -27,6 +43,12 @@ func resourcePool() *schema.Resource {
 				Type:     schema.TypeInt,
 				Required: true,
 			},
+			"include_deferred": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether deferred tasks occupy slots of the pool",
+			},
 			"occupied_slots": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -51,6 +73,10 @@ func resourcePoolCreate(ctx context.Context, d *schema.ResourceData, m interface
 	pcfg := m.(ProviderConfig)
 	client := pcfg.ApiClient
 
+	if pcfg.readOnly {
+		return diag.Errorf("failed to create pool `%s` from Airflow: %s", d.Get("name"), errReadOnly)
+	}
+
 	name := d.Get("name").(string)
 	slots := int32(d.Get("slots").(int))
 	varApi := client.PoolApi
@@ -59,10 +85,20 @@ func resourcePoolCreate(ctx context.Context, d *schema.ResourceData, m interface
 		Name:  &name,
 		Slots: &slots,
 	}
+	// Only objects created by the provider are marked, an update leaves the
+	// description of an imported pool as it is.
+	if v := pcfg.withManagedByMarker("", poolDescriptionMinVersion); v != "" {
+		pool.SetDescription(v)
+	}
 
-	_, _, err := varApi.PostPool(pcfg.AuthContext).Pool(pool).Execute()
+	var err error
+	if d.Get("include_deferred").(bool) {
+		err = sendApiRequest(pcfg.AuthContext(ctx), client, "PoolApiService.PostPool", http.MethodPost, "/pools", nil, newPoolBody(pool, true))
+	} else {
+		_, _, err = varApi.PostPool(pcfg.AuthContext(ctx)).Pool(pool).Execute()
+	}
 	if err != nil {
-		return diag.Errorf("failed to create pool `%s` from Airflow: %s", name, err)
+		return apiErrorf(d, err, "failed to create pool `%s` from Airflow", name)
 	}
 	d.SetId(name)
 
@@ -73,13 +109,27 @@ func resourcePoolRead(ctx context.Context, d *schema.ResourceData, m interface{}
 	pcfg := m.(ProviderConfig)
 	client := pcfg.ApiClient
 
-	pool, resp, err := client.PoolApi.GetPool(pcfg.AuthContext, d.Id()).Execute()
+	pool, resp, err := client.PoolApi.GetPool(pcfg.AuthContext(ctx), d.Id()).Execute()
 	if resp != nil && resp.StatusCode == 404 {
 		d.SetId("")
 		return nil
 	}
 	if err != nil {
-		return diag.Errorf("failed to get pool `%s` from Airflow: %s", d.Id(), err)
+		return apiErrorf(d, err, "failed to get pool `%s` from Airflow", d.Id())
+	}
+
+	// The generated client knows neither running_slots, which replaced
+	// used_slots in Airflow 3, nor include_deferred.
+	var fields struct {
+		RunningSlots    int32 `json:"running_slots"`
+		IncludeDeferred bool  `json:"include_deferred"`
+	}
+	if err := decodeResponseBody(resp, &fields); err != nil {
+		return diag.Errorf("failed to decode pool `%s` from Airflow: %s", d.Id(), err)
+	}
+	usedSlots := pool.GetUsedSlots()
+	if pcfg.ApiVersion == apiVersionV2 {
+		usedSlots = fields.RunningSlots
 	}
 
 	d.Set("name", pool.Name)
@@ -87,7 +137,8 @@ func resourcePoolRead(ctx context.Context, d *schema.ResourceData, m interface{}
 	d.Set("occupied_slots", pool.OccupiedSlots)
 	d.Set("queued_slots", pool.QueuedSlots)
 	d.Set("open_slots", pool.OpenSlots)
-	d.Set("used_slots", pool.UsedSlots)
+	d.Set("used_slots", usedSlots)
+	d.Set("include_deferred", fields.IncludeDeferred)
 
 	return nil
 }
@@ -96,6 +147,10 @@ func resourcePoolUpdate(ctx context.Context, d *schema.ResourceData, m interface
 	pcfg := m.(ProviderConfig)
 	client := pcfg.ApiClient
 
+	if pcfg.readOnly {
+		return diag.Errorf("failed to update pool `%s` from Airflow: %s", d.Id(), errReadOnly)
+	}
+
 	slots := int32(d.Get("slots").(int))
 	name := d.Id()
 
@@ -104,21 +159,48 @@ func resourcePoolUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		Slots: &slots,
 	}
 
-	_, _, err := client.PoolApi.PatchPool(pcfg.AuthContext, name).Pool(pool).Execute()
+	var err error
+	if d.HasChange("include_deferred") {
+		err = sendApiRequest(pcfg.AuthContext(ctx), client, "PoolApiService.PatchPool", http.MethodPatch, "/pools/"+url.PathEscape(name), nil, newPoolBody(pool, d.Get("include_deferred").(bool)))
+	} else {
+		_, _, err = client.PoolApi.PatchPool(pcfg.AuthContext(ctx), name).Pool(pool).Execute()
+	}
 	if err != nil {
-		return diag.Errorf("failed to update pool `%s` from Airflow: %s", name, err)
+		return apiErrorf(d, err, "failed to update pool `%s` from Airflow", name)
 	}
 
 	return resourcePoolRead(ctx, d, m)
 }
 
+// poolBody is a pool with the include_deferred field of Airflow 2.7, which the
+// Pool model of the generated client lacks.
+type poolBody struct {
+	Name            string  `json:"name"`
+	Slots           int32   `json:"slots"`
+	Description     *string `json:"description,omitempty"`
+	IncludeDeferred bool    `json:"include_deferred"`
+}
+
+func newPoolBody(pool airflow.Pool, includeDeferred bool) poolBody {
+	return poolBody{
+		Name:            pool.GetName(),
+		Slots:           pool.GetSlots(),
+		Description:     pool.Description.Get(),
+		IncludeDeferred: includeDeferred,
+	}
+}
+
 func resourcePoolDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
 	client := pcfg.ApiClient
 
-	resp, err := client.PoolApi.DeletePool(pcfg.AuthContext, d.Id()).Execute()
+	if pcfg.readOnly {
+		return diag.Errorf("failed to delete pool `%s` from Airflow: %s", d.Id(), errReadOnly)
+	}
+
+	resp, err := client.PoolApi.DeletePool(pcfg.AuthContext(ctx), d.Id()).Execute()
 	if err != nil {
-		return diag.Errorf("failed to delete pool `%s` from Airflow: %s", d.Id(), err)
+		return apiErrorf(d, err, "failed to delete pool `%s` from Airflow", d.Id())
 	}
 
 	if resp != nil && resp.StatusCode == 404 {
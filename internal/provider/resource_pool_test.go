@@ -1,11 +1,21 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
+	"github.com/apache/airflow-client-go/airflow"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -43,6 +53,110 @@ func TestAccAirflowPool_basic(t *testing.T) {
 	})
 }
 
+func TestPoolIncludeDeferred(t *testing.T) {
+	requests := make(map[string]map[string]interface{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, _, ok := r.BasicAuth(); !ok || username != "admin" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodGet {
+			body, _ := io.ReadAll(r.Body)
+			var pool map[string]interface{}
+			json.Unmarshal(body, &pool)
+			requests[r.Method] = pool
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"name": "foo", "slots": 2, "include_deferred": true}`)
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	pcfg := ProviderConfig{
+		ApiClient:  newApiClient(u, apiServerPath("", apiVersionV1), server.Client(), false),
+		ApiVersion: apiVersionV1,
+		basicAuth:  &airflow.BasicAuth{UserName: "admin", Password: "secret"},
+	}
+
+	d := resourcePool().Data(nil)
+	d.Set("name", "foo")
+	d.Set("slots", 2)
+	d.Set("include_deferred", true)
+	if diags := resourcePoolCreate(context.Background(), d, pcfg); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got := requests[http.MethodPost]["include_deferred"]; got != true {
+		t.Errorf("expected include_deferred to be sent on create, got %v", got)
+	}
+	if !d.Get("include_deferred").(bool) {
+		t.Error("expected include_deferred to be read back")
+	}
+
+	d = resourcePool().Data(&terraform.InstanceState{ID: "foo", Attributes: map[string]string{"include_deferred": "true"}})
+	d.Set("slots", 3)
+	if diags := resourcePoolUpdate(context.Background(), d, pcfg); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if _, ok := requests[http.MethodPatch]["include_deferred"]; ok {
+		t.Errorf("expected include_deferred to be left out when unchanged, got %v", requests[http.MethodPatch])
+	}
+}
+
+func TestPoolIncludeDeferredApiError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"detail": "'yes' is not of type 'boolean' - 'include_deferred'", "status": 400, "title": "Bad Request", "type": "about:blank"}`)
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	pcfg := ProviderConfig{
+		ApiClient:  newApiClient(u, apiServerPath("", apiVersionV1), server.Client(), false),
+		ApiVersion: apiVersionV1,
+	}
+
+	d := schema.TestResourceDataRaw(t, resourcePool().Schema, map[string]interface{}{
+		"name":             "foo",
+		"slots":            2,
+		"include_deferred": true,
+	})
+	diags := resourcePoolCreate(context.Background(), d, pcfg)
+	if len(diags) != 1 {
+		t.Fatalf("expected one diagnostic, got %v", diags)
+	}
+	if expected := "failed to create pool `foo` from Airflow: Bad Request"; diags[0].Summary != expected {
+		t.Errorf("expected summary %q, got %q", expected, diags[0].Summary)
+	}
+	if !strings.Contains(diags[0].Detail, "HTTP status: 400 Bad Request") {
+		t.Errorf("expected the HTTP status in the detail, got %q", diags[0].Detail)
+	}
+
+	err := sendApiRequest(context.Background(), pcfg.ApiClient, "PoolApiService.PostPool", http.MethodPost, "/pools", nil, newPoolBody(airflow.Pool{}, true))
+	if problem := parseApiProblem(err); problem == nil || problem.Field != "include_deferred" {
+		t.Errorf("expected a problem on include_deferred, got %#v", problem)
+	}
+}
+
+func TestPoolIncludeDeferredRequiresAirflowVersion(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":             "foo",
+		"slots":            2,
+		"include_deferred": true,
+	})
+
+	pcfg := ProviderConfig{AirflowVersion: version.Must(version.NewVersion("2.6.3"))}
+	_, err := resourcePool().SimpleDiff(context.Background(), nil, config, pcfg)
+	if err == nil || !strings.Contains(err.Error(), "`include_deferred` requires Airflow 2.7.0 or later") {
+		t.Errorf("expected include_deferred to be refused on Airflow 2.6.3, got %v", err)
+	}
+
+	pcfg.AirflowVersion = version.Must(version.NewVersion("2.7.0"))
+	if _, err := resourcePool().SimpleDiff(context.Background(), nil, config, pcfg); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
 func testAccCheckAirflowPoolCheckDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(ProviderConfig)
 
@@ -51,7 +165,7 @@ func testAccCheckAirflowPoolCheckDestroy(s *terraform.State) error {
 			continue
 		}
 
-		variable, res, err := client.ApiClient.PoolApi.GetPool(client.AuthContext, rs.Primary.ID).Execute()
+		variable, res, err := client.ApiClient.PoolApi.GetPool(client.AuthContext(context.Background()), rs.Primary.ID).Execute()
 		if err == nil {
 			if *variable.Name == rs.Primary.ID {
 				return fmt.Errorf("Airflow Pool (%s) still exists.", rs.Primary.ID)
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/airflow-client-go/airflow"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -10,13 +11,20 @@ import (
 
 func resourceRole() *schema.Resource {
 	return &schema.Resource{
-		CreateWithoutTimeout: resourceRoleCreate,
-		ReadWithoutTimeout:   resourceRoleRead,
-		UpdateWithoutTimeout: resourceRoleUpdate,
-		DeleteWithoutTimeout: resourceRoleDelete,
+		CreateContext: resourceRoleCreate,
+		ReadContext:   resourceRoleRead,
+		UpdateContext: resourceRoleUpdate,
+		DeleteContext: resourceRoleDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: readOnlyCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -45,7 +53,11 @@ func resourceRole() *schema.Resource {
 
 func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
+	client := pcfg.FabApiClient
+
+	if pcfg.readOnly {
+		return diag.Errorf("failed to create role `%s` from Airflow: %s", d.Get("name"), errReadOnly)
+	}
 
 	name := d.Get("name").(string)
 	varApi := client.RoleApi
@@ -58,9 +70,9 @@ func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface
 		role.Actions = &actions
 	}
 
-	_, _, err := varApi.PostRole(pcfg.AuthContext).Role(role).Execute()
+	_, _, err := varApi.PostRole(pcfg.AuthContext(ctx)).Role(role).Execute()
 	if err != nil {
-		return diag.Errorf("failed to create role `%s` from Airflow: %s", name, err)
+		return apiErrorf(d, err, "failed to create role `%s` from Airflow", name)
 	}
 	d.SetId(name)
 
@@ -69,15 +81,15 @@ func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
+	client := pcfg.FabApiClient
 
-	role, resp, err := client.RoleApi.GetRole(pcfg.AuthContext, d.Id()).Execute()
+	role, resp, err := client.RoleApi.GetRole(pcfg.AuthContext(ctx), d.Id()).Execute()
 	if resp != nil && resp.StatusCode == 404 {
 		d.SetId("")
 		return nil
 	}
 	if err != nil {
-		return diag.Errorf("failed to get role `%s` from Airflow: %s", d.Id(), err)
+		return apiErrorf(d, err, "failed to get role `%s` from Airflow", d.Id())
 	}
 
 	d.Set("name", role.Name)
@@ -90,7 +102,11 @@ func resourceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}
 
 func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
+	client := pcfg.FabApiClient
+
+	if pcfg.readOnly {
+		return diag.Errorf("failed to update role `%s` from Airflow: %s", d.Id(), errReadOnly)
+	}
 
 	name := d.Id()
 	actions := expandAirflowRoleActions(d.Get("action").(*schema.Set).List())
@@ -99,9 +115,9 @@ func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		Actions: &actions,
 	}
 
-	_, _, err := client.RoleApi.PatchRole(pcfg.AuthContext, name).Role(role).Execute()
+	_, _, err := client.RoleApi.PatchRole(pcfg.AuthContext(ctx), name).Role(role).Execute()
 	if err != nil {
-		return diag.Errorf("failed to update role `%s` from Airflow: %s", name, err)
+		return apiErrorf(d, err, "failed to update role `%s` from Airflow", name)
 	}
 
 	return resourceRoleRead(ctx, d, m)
@@ -109,11 +125,15 @@ func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
+	client := pcfg.FabApiClient
+
+	if pcfg.readOnly {
+		return diag.Errorf("failed to delete role `%s` from Airflow: %s", d.Id(), errReadOnly)
+	}
 
-	resp, err := client.RoleApi.DeleteRole(pcfg.AuthContext, d.Id()).Execute()
+	resp, err := client.RoleApi.DeleteRole(pcfg.AuthContext(ctx), d.Id()).Execute()
 	if err != nil {
-		return diag.Errorf("failed to delete role `%s` from Airflow: %s", d.Id(), err)
+		return apiErrorf(d, err, "failed to delete role `%s` from Airflow", d.Id())
 	}
 
 	if resp != nil && resp.StatusCode == 404 {
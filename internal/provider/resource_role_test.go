@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -46,7 +47,7 @@ func testAccCheckAirflowRoleCheckDestroy(s *terraform.State) error {
 			continue
 		}
 
-		variable, res, err := client.ApiClient.RoleApi.GetRole(client.AuthContext, rs.Primary.ID).Execute()
+		variable, res, err := client.FabApiClient.RoleApi.GetRole(client.AuthContext(context.Background()), rs.Primary.ID).Execute()
 		if err == nil {
 			if *variable.Name == rs.Primary.ID {
 				return fmt.Errorf("Airflow Role (%s) still exists.", rs.Primary.ID)
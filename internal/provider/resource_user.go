@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/airflow-client-go/airflow"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -10,13 +11,20 @@ import (
 
 func resourceUser() *schema.Resource {
 	return &schema.Resource{
-		CreateWithoutTimeout: resourceUserCreate,
-		ReadWithoutTimeout:   resourceUserRead,
-		UpdateWithoutTimeout: resourceUserUpdate,
-		DeleteWithoutTimeout: resourceUserDelete,
+		CreateContext: resourceUserCreate,
+		ReadContext:   resourceUserRead,
+		UpdateContext: resourceUserUpdate,
+		DeleteContext: resourceUserDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: readOnlyCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"active": {
 				Type:     schema.TypeBool,
@@ -65,7 +73,11 @@ func resourceUser() *schema.Resource {
 
 func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
+	client := pcfg.FabApiClient
+
+	if pcfg.readOnly {
+		return diag.Errorf("failed to create user `%s` from Airflow: %s", d.Get("email"), errReadOnly)
+	}
 
 	email := d.Get("email").(string)
 	firstName := d.Get("first_name").(string)
@@ -76,7 +88,7 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 
 	userApi := client.UserApi
 
-	_, _, err := userApi.PostUser(pcfg.AuthContext).User(airflow.User{
+	_, _, err := userApi.PostUser(pcfg.AuthContext(ctx)).User(airflow.User{
 		Email:     &email,
 		FirstName: &firstName,
 		LastName:  &lastName,
@@ -85,7 +97,7 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 		Roles:     &roles,
 	}).Execute()
 	if err != nil {
-		return diag.Errorf("failed to create user `%s` from Airflow: %s", email, err)
+		return apiErrorf(d, err, "failed to create user `%s` from Airflow", email)
 	}
 	d.SetId(username)
 
@@ -94,15 +106,15 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
+	client := pcfg.FabApiClient
 
-	user, resp, err := client.UserApi.GetUser(pcfg.AuthContext, d.Id()).Execute()
+	user, resp, err := client.UserApi.GetUser(pcfg.AuthContext(ctx), d.Id()).Execute()
 	if resp != nil && resp.StatusCode == 404 {
 		d.SetId("")
 		return nil
 	}
 	if err != nil {
-		return diag.Errorf("failed to get user `%s` from Airflow: %s", d.Id(), err)
+		return apiErrorf(d, err, "failed to get user `%s` from Airflow", d.Id())
 	}
 
 	d.Set("active", user.GetActive())
@@ -120,7 +132,11 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 
 func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
+	client := pcfg.FabApiClient
+
+	if pcfg.readOnly {
+		return diag.Errorf("failed to update user `%s` from Airflow: %s", d.Id(), errReadOnly)
+	}
 
 	email := d.Get("email").(string)
 	firstName := d.Get("first_name").(string)
@@ -129,7 +145,7 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 	roles := expandAirflowUserRoles(d.Get("roles").(*schema.Set))
 	username := d.Id()
 
-	_, _, err := client.UserApi.PatchUser(pcfg.AuthContext, username).User(airflow.User{
+	_, _, err := client.UserApi.PatchUser(pcfg.AuthContext(ctx), username).User(airflow.User{
 		Email:     &email,
 		FirstName: &firstName,
 		LastName:  &lastName,
@@ -138,7 +154,7 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		Username:  &username,
 	}).Execute()
 	if err != nil {
-		return diag.Errorf("failed to update user `%s` from Airflow: %s", email, err)
+		return apiErrorf(d, err, "failed to update user `%s` from Airflow", email)
 	}
 
 	return resourceUserRead(ctx, d, m)
@@ -146,11 +162,15 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
+	client := pcfg.FabApiClient
+
+	if pcfg.readOnly {
+		return diag.Errorf("failed to delete user `%s` from Airflow: %s", d.Id(), errReadOnly)
+	}
 
-	resp, err := client.UserApi.DeleteUser(pcfg.AuthContext, d.Id()).Execute()
+	resp, err := client.UserApi.DeleteUser(pcfg.AuthContext(ctx), d.Id()).Execute()
 	if err != nil {
-		return diag.Errorf("failed to delete user `%s` from Airflow: %s", d.Id(), err)
+		return apiErrorf(d, err, "failed to delete user `%s` from Airflow", d.Id())
 	}
 
 	if resp != nil && resp.StatusCode == 404 {
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -63,7 +64,7 @@ func testAccCheckAirflowUserCheckDestroy(s *terraform.State) error {
 			continue
 		}
 
-		user, res, err := client.ApiClient.UserApi.GetUser(client.AuthContext, rs.Primary.ID).Execute()
+		user, res, err := client.FabApiClient.UserApi.GetUser(client.AuthContext(context.Background()), rs.Primary.ID).Execute()
 		if err == nil {
 			if *user.Username == rs.Primary.ID {
 				return fmt.Errorf("Airflow User (%s) still exists.", rs.Primary.ID)
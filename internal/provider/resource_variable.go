@@ -2,103 +2,337 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/apache/airflow-client-go/airflow"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-func resourceVariable() *schema.Resource {
-	return &schema.Resource{
-		CreateWithoutTimeout: resourceVariableCreate,
-		ReadWithoutTimeout:   resourceVariableRead,
-		UpdateWithoutTimeout: resourceVariableUpdate,
-		DeleteWithoutTimeout: resourceVariableDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
-		Schema: map[string]*schema.Schema{
-			"key": {
-				Type:     schema.TypeString,
+const variableDefaultTimeout = 5 * time.Minute
+
+var (
+	_ resource.ResourceWithConfigure   = &variableResource{}
+	_ resource.ResourceWithImportState = &variableResource{}
+	_ resource.ResourceWithModifyPlan  = &variableResource{}
+)
+
+type variableResource struct {
+	providerData any
+}
+
+type variableResourceModel struct {
+	Id       types.String   `tfsdk:"id"`
+	Key      types.String   `tfsdk:"key"`
+	Value    types.String   `tfsdk:"value"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func newVariableResource() resource.Resource {
+	return &variableResource{}
+}
+
+func (r *variableResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_variable"
+}
+
+func (r *variableResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key": schema.StringAttribute{
 				Required: true,
-				ForceNew: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
-			"value": {
-				Type:     schema.TypeString,
+			"value": schema.StringAttribute{
 				Required: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *variableResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	r.providerData = req.ProviderData
+}
+
+func (r *variableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ModifyPlan fails plans that would change or destroy variables while the
+// provider is read only, like readOnlyCustomizeDiff and
+// readOnlyProviderServer do for the SDK resources.
+func (r *variableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.providerData == nil {
+		return
+	}
+	pcfg, diags := frameworkProviderConfig(r.providerData)
+	if diags.HasError() || !pcfg.readOnly {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		var state variableResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.AddError(fmt.Sprintf("cannot delete `%s` in Airflow: %s", state.Id.ValueString(), errReadOnly), "")
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		resp.Diagnostics.AddError(fmt.Sprintf("cannot create this object in Airflow: %s", errReadOnly), "")
+		return
+	}
+
+	var state variableResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan variableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Value.Equal(state.Value) {
+		resp.Diagnostics.AddError(fmt.Sprintf("cannot change value of `%s` in Airflow: %s", state.Id.ValueString(), errReadOnly), "")
 	}
 }
 
-func resourceVariableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
+func (r *variableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan variableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pcfg, diags := frameworkProviderConfig(r.providerData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key := plan.Key.ValueString()
+	val := plan.Value.ValueString()
+
+	ctx, end := startOperation(ctx, pcfg, "airflow_variable", "create", func() string { return plan.Id.ValueString() })
+	defer func() { end(frameworkErrorSummary(resp.Diagnostics)) }()
 
-	key := d.Get("key").(string)
-	val := d.Get("value").(string)
-	varApi := client.VariableApi
+	if pcfg.readOnly {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to create variable `%s` from Airflow: %s", key, errReadOnly), "")
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Create(ctx, variableDefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	_, _, err := varApi.PostVariables(pcfg.AuthContext).Variable(airflow.Variable{
+	variable := airflow.Variable{
 		Key:   &key,
 		Value: &val,
-	}).Execute()
+	}
+	// Only objects created by the provider are marked, an update leaves the
+	// description of an imported variable as it is.
+	if v := pcfg.withManagedByMarker("", variableDescriptionMinVersion); v != "" {
+		variable.SetDescription(v)
+	}
+
+	_, _, err := pcfg.ApiClient.VariableApi.PostVariables(pcfg.AuthContext(ctx)).Variable(variable).Execute()
 	if err != nil {
-		return diag.Errorf("failed to create variable `%s` from Airflow: %s", key, err)
+		resp.Diagnostics.Append(frameworkDiags(apiErrorf(nil, err, "failed to create variable `%s` from Airflow", key))...)
+		return
+	}
+	plan.Id = types.StringValue(key)
+
+	found, diags := r.read(ctx, pcfg, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to get variable `%s` from Airflow: not found after creation", key), "")
+		return
 	}
-	d.SetId(key)
 
-	return resourceVariableRead(ctx, d, m)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
-func resourceVariableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
+func (r *variableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state variableResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	variable, resp, err := client.VariableApi.GetVariable(pcfg.AuthContext, d.Id()).Execute()
-	if resp != nil && resp.StatusCode == 404 {
-		d.SetId("")
-		return nil
+	pcfg, diags := frameworkProviderConfig(r.providerData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if err != nil {
-		return diag.Errorf("failed to get variable `%s` from Airflow: %s", d.Id(), err)
+
+	ctx, end := startOperation(ctx, pcfg, "airflow_variable", "read", state.Id.ValueString)
+	defer func() { end(frameworkErrorSummary(resp.Diagnostics)) }()
+
+	timeout, diags := state.Timeouts.Read(ctx, variableDefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	d.Set("key", variable.Key)
-	d.Set("value", variable.Value)
+	found, diags := r.read(ctx, pcfg, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
-	return nil
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
-func resourceVariableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
+func (r *variableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan variableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pcfg, diags := frameworkProviderConfig(r.providerData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key := plan.Id.ValueString()
+	val := plan.Value.ValueString()
+
+	ctx, end := startOperation(ctx, pcfg, "airflow_variable", "update", plan.Id.ValueString)
+	defer func() { end(frameworkErrorSummary(resp.Diagnostics)) }()
+
+	if pcfg.readOnly {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to update variable `%s` from Airflow: %s", key, errReadOnly), "")
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Update(ctx, variableDefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	val := d.Get("value").(string)
-	key := d.Id()
-	_, _, err := client.VariableApi.PatchVariable(pcfg.AuthContext, key).Variable(airflow.Variable{
+	variable := airflow.Variable{
 		Key:   &key,
 		Value: &val,
-	}).Execute()
+	}
+
+	_, _, err := pcfg.ApiClient.VariableApi.PatchVariable(pcfg.AuthContext(ctx), key).Variable(variable).Execute()
 	if err != nil {
-		return diag.Errorf("failed to update variable `%s` from Airflow: %s", key, err)
+		resp.Diagnostics.Append(frameworkDiags(apiErrorf(nil, err, "failed to update variable `%s` from Airflow", key))...)
+		return
+	}
+
+	found, diags := r.read(ctx, pcfg, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to get variable `%s` from Airflow: not found after update", key), "")
+		return
 	}
 
-	return resourceVariableRead(ctx, d, m)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
-func resourceVariableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
+func (r *variableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state variableResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pcfg, diags := frameworkProviderConfig(r.providerData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key := state.Id.ValueString()
 
-	resp, err := client.VariableApi.DeleteVariable(pcfg.AuthContext, d.Id()).Execute()
+	ctx, end := startOperation(ctx, pcfg, "airflow_variable", "delete", state.Id.ValueString)
+	defer func() { end(frameworkErrorSummary(resp.Diagnostics)) }()
+
+	if pcfg.readOnly {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to delete variable `%s` from Airflow: %s", key, errReadOnly), "")
+		return
+	}
+
+	timeout, diags := state.Timeouts.Delete(ctx, variableDefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, err := pcfg.ApiClient.VariableApi.DeleteVariable(pcfg.AuthContext(ctx), key).Execute()
 	if err != nil {
-		return diag.Errorf("failed to delete variable `%s` from Airflow: %s", d.Id(), err)
+		resp.Diagnostics.Append(frameworkDiags(apiErrorf(nil, err, "failed to delete variable `%s` from Airflow", key))...)
 	}
+}
+
+// read refreshes model from Airflow, reporting whether the variable still
+// exists.
+func (r *variableResource) read(ctx context.Context, pcfg ProviderConfig, model *variableResourceModel) (bool, fwdiag.Diagnostics) {
+	var diags fwdiag.Diagnostics
 
-	if resp != nil && resp.StatusCode == 404 {
-		return nil
+	variable, res, err := pcfg.ApiClient.VariableApi.GetVariable(pcfg.AuthContext(ctx), model.Id.ValueString()).Execute()
+	if res != nil && res.StatusCode == 404 {
+		return false, diags
 	}
+	if err != nil {
+		diags.Append(frameworkDiags(apiErrorf(nil, err, "failed to get variable `%s` from Airflow", model.Id.ValueString()))...)
+		return false, diags
+	}
+
+	model.Key = types.StringPointerValue(variable.Key)
+	model.Value = types.StringPointerValue(variable.Value)
 
-	return nil
+	return true, diags
 }
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -15,9 +16,9 @@ func TestAccAirflowVariable_basic(t *testing.T) {
 
 	resourceName := "airflow_variable.test"
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckAirflowVariableCheckDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckAirflowVariableCheckDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccAirflowVariableConfigBasic(rName, rName),
@@ -50,7 +51,7 @@ func testAccCheckAirflowVariableCheckDestroy(s *terraform.State) error {
 			continue
 		}
 
-		variable, res, err := client.ApiClient.VariableApi.GetVariable(client.AuthContext, rs.Primary.ID).Execute()
+		variable, res, err := client.ApiClient.VariableApi.GetVariable(client.AuthContext(context.Background()), rs.Primary.ID).Execute()
 		if err == nil {
 			if *variable.Key == rs.Primary.ID {
 				return fmt.Errorf("Airflow Variable (%s) still exists.", rs.Primary.ID)
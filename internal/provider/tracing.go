@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName         = "github.com/drfaust92/terraform-provider-airflow"
+	tracingServiceName = "terraform-provider-airflow"
+	// Terraform kills the plugin two seconds after asking it to stop.
+	tracingShutdownWait = time.Second
+
+	attributeResourceType = attribute.Key("airflow.resource_type")
+	attributeOperation    = attribute.Key("airflow.operation")
+	attributeObjectId     = attribute.Key("airflow.object_id")
+)
+
+// The tracer provider is shared by every configured instance of the provider
+// in the plugin process.
+var (
+	tracerProviderOnce sync.Once
+	tracerProvider     *sdktrace.TracerProvider
+	tracerProviderErr  error
+)
+
+// tracingEnabled follows the OpenTelemetry SDK environment variables: traces
+// are exported over OTLP once an endpoint is set, unless the SDK or the
+// traces exporter is disabled.
+func tracingEnabled() bool {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return false
+	}
+
+	if v := os.Getenv("OTEL_TRACES_EXPORTER"); v != "" {
+		return v == "otlp"
+	}
+
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+func newTracerProvider(ctx context.Context) (*sdktrace.TracerProvider, error) {
+	tracerProviderOnce.Do(func() {
+		if !tracingEnabled() {
+			return
+		}
+
+		protocol := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")
+		if protocol == "" {
+			protocol = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+		}
+
+		var exporter *otlptrace.Exporter
+		switch protocol {
+		case "", "http/protobuf":
+			exporter, tracerProviderErr = otlptracehttp.New(ctx)
+		case "grpc":
+			exporter, tracerProviderErr = otlptracegrpc.New(ctx)
+		default:
+			tracerProviderErr = fmt.Errorf("unsupported OTLP protocol `%s`, use http/protobuf or grpc", protocol)
+		}
+		if tracerProviderErr != nil {
+			return
+		}
+
+		// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES take precedence over
+		// the default service name.
+		res, err := resource.New(ctx,
+			resource.WithAttributes(attribute.String("service.name", tracingServiceName)),
+			resource.WithFromEnv(),
+			resource.WithTelemetrySDK(),
+		)
+		if err != nil {
+			tracerProviderErr = err
+			return
+		}
+
+		tracerProvider = sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(res),
+		)
+	})
+
+	return tracerProvider, tracerProviderErr
+}
+
+// newTracingTransport records a span for every request, with the W3C trace
+// context propagated to Airflow, and tags it with the operation in progress.
+func newTracingTransport(base http.RoundTripper, tp trace.TracerProvider) http.RoundTripper {
+	return otelhttp.NewTransport(&operationAttributesTransport{base: base},
+		otelhttp.WithTracerProvider(tp),
+		otelhttp.WithPropagators(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})),
+	)
+}
+
+type operationAttributesTransport struct {
+	base http.RoundTripper
+}
+
+func (t *operationAttributesTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if op, ok := req.Context().Value(tracedOperationKey{}).(*tracedOperation); ok {
+		trace.SpanFromContext(req.Context()).SetAttributes(op.attributes()...)
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+type tracedOperationKey struct{}
+
+type tracedOperation struct {
+	resourceType string
+	operation    string
+	objectId     func() string
+}
+
+func (op *tracedOperation) attributes() []attribute.KeyValue {
+	attributes := []attribute.KeyValue{
+		attributeResourceType.String(op.resourceType),
+		attributeOperation.String(op.operation),
+	}
+	// The ID of an object being created is only known once Airflow created it.
+	if id := op.objectId(); id != "" {
+		attributes = append(attributes, attributeObjectId.String(id))
+	}
+
+	return attributes
+}
+
+// traceOperations wraps the CRUD functions of a resource or data source so
+// that their API requests are grouped under a span of the operation.
+func traceOperations(resourceType string, r *schema.Resource) {
+	r.CreateContext = traceOperation(resourceType, "create", r.CreateContext)
+	r.ReadContext = traceOperation(resourceType, "read", r.ReadContext)
+	r.UpdateContext = traceOperation(resourceType, "update", r.UpdateContext)
+	r.DeleteContext = traceOperation(resourceType, "delete", r.DeleteContext)
+}
+
+func traceOperation(resourceType, operation string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		pcfg, _ := m.(ProviderConfig)
+		ctx, end := startOperation(ctx, pcfg, resourceType, operation, d.Id)
+
+		diags := f(ctx, d, m)
+
+		var errSummary string
+		for _, diagnostic := range diags {
+			if diagnostic.Severity == diag.Error {
+				errSummary = diagnostic.Summary
+				break
+			}
+		}
+		end(errSummary)
+
+		return diags
+	}
+}
+
+// startOperation marks ctx with the operation in progress, so that its API
+// requests are tagged with it, and starts its span when tracing is enabled.
+// The returned function ends the span, as failed if given an error summary.
+func startOperation(ctx context.Context, pcfg ProviderConfig, resourceType, operation string, objectId func() string) (context.Context, func(string)) {
+	op := &tracedOperation{
+		resourceType: resourceType,
+		operation:    operation,
+		objectId:     objectId,
+	}
+	ctx = context.WithValue(ctx, tracedOperationKey{}, op)
+
+	if pcfg.tracerProvider == nil {
+		return ctx, func(string) {}
+	}
+
+	ctx, span := pcfg.tracerProvider.Tracer(tracerName).Start(ctx, fmt.Sprintf("%s.%s", resourceType, operation))
+
+	return ctx, func(errSummary string) {
+		span.SetAttributes(op.attributes()...)
+		if errSummary != "" {
+			span.SetStatus(codes.Error, errSummary)
+		}
+		span.End()
+	}
+}
+
+// ShutdownTracing exports the spans left in the batch and shuts the tracer
+// provider down. It is called once the plugin server has stopped.
+func ShutdownTracing() error {
+	if tracerProvider == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownWait)
+	defer cancel()
+
+	return tracerProvider.Shutdown(ctx)
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingEnabled(t *testing.T) {
+	cases := []struct {
+		env      map[string]string
+		expected bool
+	}{
+		{env: map[string]string{}, expected: false},
+		{env: map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318"}, expected: true},
+		{env: map[string]string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://localhost:4318/v1/traces"}, expected: true},
+		{env: map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318", "OTEL_TRACES_EXPORTER": "none"}, expected: false},
+		{env: map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318", "OTEL_SDK_DISABLED": "true"}, expected: false},
+		{env: map[string]string{"OTEL_TRACES_EXPORTER": "otlp"}, expected: true},
+	}
+
+	for _, tc := range cases {
+		for _, k := range []string{"OTEL_SDK_DISABLED", "OTEL_TRACES_EXPORTER", "OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"} {
+			t.Setenv(k, tc.env[k])
+		}
+
+		if got := tracingEnabled(); got != tc.expected {
+			t.Errorf("expected %t for %v, got %t", tc.expected, tc.env, got)
+		}
+	}
+}
+
+func TestTraceOperation(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"name": "foo", "slots": 1}`)
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	u, _ := url.Parse(server.URL)
+	client := &http.Client{Transport: newTracingTransport(http.DefaultTransport, tp)}
+	pcfg := ProviderConfig{
+		ApiClient:      newApiClient(u, apiServerPath("", apiVersionV1), client, false),
+		tracerProvider: tp,
+	}
+
+	r := resourcePool()
+	traceOperations("airflow_pool", r)
+
+	d := r.Data(&terraform.InstanceState{ID: "foo"})
+	if diags := r.ReadContext(context.Background(), d, pcfg); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected an operation and a request span, got %d", len(spans))
+	}
+	request, operation := spans[0], spans[1]
+
+	if operation.Name() != "airflow_pool.read" {
+		t.Errorf("unexpected operation span name %q", operation.Name())
+	}
+	if request.Parent().SpanID() != operation.SpanContext().SpanID() {
+		t.Error("expected the request span to be a child of the operation span")
+	}
+	if traceparent == "" {
+		t.Error("expected the traceparent header to be propagated")
+	}
+
+	for _, span := range spans {
+		attributes := make(map[attribute.Key]string)
+		for _, kv := range span.Attributes() {
+			attributes[kv.Key] = kv.Value.Emit()
+		}
+		if attributes[attributeResourceType] != "airflow_pool" || attributes[attributeOperation] != "read" || attributes[attributeObjectId] != "foo" {
+			t.Errorf("unexpected attributes on span %q: %v", span.Name(), attributes)
+		}
+	}
+}
+
+// keptSpansExporter keeps the exported spans on shutdown, unlike the in-memory
+// exporter it wraps.
+type keptSpansExporter struct {
+	*tracetest.InMemoryExporter
+}
+
+func (keptSpansExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func TestShutdownTracing(t *testing.T) {
+	exporter := keptSpansExporter{tracetest.NewInMemoryExporter()}
+	tracerProvider = sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	defer func() { tracerProvider = nil }()
+
+	_, end := startOperation(context.Background(), ProviderConfig{tracerProvider: tracerProvider}, "airflow_pool", "read", func() string { return "foo" })
+	end("")
+
+	if spans := exporter.GetSpans(); len(spans) != 0 {
+		t.Fatalf("expected the span to wait in the batch, got %d exported", len(spans))
+	}
+
+	if err := ShutdownTracing(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if spans := exporter.GetSpans(); len(spans) != 1 || spans[0].Name != "airflow_pool.read" {
+		t.Errorf("expected the span to be exported on shutdown, got %v", spans)
+	}
+}
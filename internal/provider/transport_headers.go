@@ -0,0 +1,21 @@
+package provider
+
+import (
+	"net/http"
+)
+
+// headerTransport adds static headers, such as API gateway keys, to every
+// request sent to Airflow.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	for k, v := range t.headers {
+		r.Header.Set(k, v)
+	}
+
+	return t.base.RoundTrip(r)
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// limitTransport throttles the requests of all resources sharing the provider,
+// however high Terraform's parallelism is set. Each retry attempt counts as a
+// request, the wait between attempts does not hold a slot.
+type limitTransport struct {
+	base    http.RoundTripper
+	slots   chan struct{}
+	limiter *rate.Limiter
+}
+
+func newLimitTransport(base http.RoundTripper, maxConcurrent int, perSecond float64) http.RoundTripper {
+	if maxConcurrent <= 0 && perSecond <= 0 {
+		return base
+	}
+
+	t := &limitTransport{base: base}
+	if maxConcurrent > 0 {
+		t.slots = make(chan struct{}, maxConcurrent)
+	}
+	if perSecond > 0 {
+		t.limiter = rate.NewLimiter(rate.Limit(perSecond), int(math.Max(1, math.Ceil(perSecond))))
+	}
+
+	return t
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			t.release()
+			return nil, err
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || t.slots == nil {
+		t.release()
+		return resp, err
+	}
+
+	// The slot is held until the response body has been consumed.
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: t.release}
+
+	return resp, nil
+}
+
+func (t *limitTransport) release() {
+	if t.slots != nil {
+		<-t.slots
+	}
+}
+
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
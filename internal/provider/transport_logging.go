@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+)
+
+const redactedValue = "REDACTED"
+
+// defaultRedactFields covers connection and user passwords, connection
+// extras, variable values and the credentials exchanged with identity
+// providers.
+var defaultRedactFields = []string{
+	"password",
+	"extra",
+	"value",
+	"secret",
+	"client_secret",
+	"token",
+	"access_token",
+	"id_token",
+	"refresh_token",
+	"assertion",
+}
+
+var redactedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// redactingLoggingTransport logs requests and responses at debug level like
+// the SDK logging transport, with auth headers, the extra headers and
+// sensitive fields of JSON and form bodies masked.
+type redactingLoggingTransport struct {
+	base    http.RoundTripper
+	fields  map[string]bool
+	headers map[string]bool
+}
+
+func newRedactingLoggingTransport(base http.RoundTripper, extraFields, extraHeaders []string) *redactingLoggingTransport {
+	fields := make(map[string]bool)
+	for _, field := range append(append([]string{}, defaultRedactFields...), extraFields...) {
+		fields[strings.ToLower(field)] = true
+	}
+
+	headers := make(map[string]bool)
+	for _, header := range append(append([]string{}, redactedHeaders...), extraHeaders...) {
+		headers[http.CanonicalHeaderKey(header)] = true
+	}
+
+	return &redactingLoggingTransport{
+		base:    base,
+		fields:  fields,
+		headers: headers,
+	}
+}
+
+func (t *redactingLoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !logging.IsDebugOrHigher() {
+		return t.base.RoundTrip(req)
+	}
+
+	var reqBody []byte
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			reqBody, _ = io.ReadAll(body)
+			body.Close()
+		}
+	}
+	log.Printf("[DEBUG] Airflow API Request Details:\n---[ REQUEST ]---------------------------------------\n%s %s\n%s\n%s\n-----------------------------------------------------",
+		req.Method, req.URL.RequestURI(), t.redactHeaders(req.Header), t.redactBody(req.Header.Get("Content-Type"), reqBody))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	log.Printf("[DEBUG] Airflow API Response Details:\n---[ RESPONSE ]--------------------------------------\n%s %s\n%s\n%s\n-----------------------------------------------------",
+		resp.Proto, resp.Status, t.redactHeaders(resp.Header), t.redactBody(resp.Header.Get("Content-Type"), respBody))
+
+	return resp, nil
+}
+
+func (t *redactingLoggingTransport) redactHeaders(header http.Header) string {
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		for _, v := range header[k] {
+			if t.isRedactedHeader(k) {
+				v = redactedValue
+			}
+			fmt.Fprintf(&b, "%s: %s\n", k, v)
+		}
+	}
+
+	return b.String()
+}
+
+func (t *redactingLoggingTransport) isRedactedHeader(name string) bool {
+	return t.headers[http.CanonicalHeaderKey(name)] || t.fields[strings.ToLower(name)]
+}
+
+func (t *redactingLoggingTransport) redactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		var v interface{}
+		if err := json.Unmarshal(body, &v); err != nil {
+			return "[unparseable JSON body omitted]"
+		}
+		redacted, err := json.MarshalIndent(t.redactJSON(v), "", "  ")
+		if err != nil {
+			return "[unparseable JSON body omitted]"
+		}
+		return string(redacted)
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return "[unparseable form body omitted]"
+		}
+		for k := range values {
+			if t.fields[strings.ToLower(k)] {
+				values[k] = []string{redactedValue}
+			}
+		}
+		return values.Encode()
+	}
+
+	return string(body)
+}
+
+func (t *redactingLoggingTransport) redactJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			if t.fields[strings.ToLower(k)] && item != nil {
+				v[k] = redactedValue
+			} else {
+				v[k] = t.redactJSON(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = t.redactJSON(item)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRedactingLoggingTransport(t *testing.T) {
+	t.Setenv("TF_LOG", "DEBUG")
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=cookie-secret")
+		fmt.Fprint(w, `{"connection_id": "db", "password": "response-secret", "extra": "{\"token\": \"extra-secret\"}"}`)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: newRedactingLoggingTransport(http.DefaultTransport, []string{"X-Api-Key", "api_secret"}, nil),
+	}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"connection_id": "db", "password": "request-secret", "nested": [{"api_secret": "nested-secret"}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer header-secret")
+	req.Header.Set("X-Api-Key", "key-secret")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if !strings.Contains(string(body), "response-secret") {
+		t.Errorf("expected response body to be passed through unchanged, got %s", body)
+	}
+
+	logged := buf.String()
+	for _, secret := range []string{"request-secret", "nested-secret", "header-secret", "key-secret", "response-secret", "extra-secret", "cookie-secret"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("expected %s to be redacted from log:\n%s", secret, logged)
+		}
+	}
+	if !strings.Contains(logged, `"connection_id": "db"`) {
+		t.Errorf("expected non-sensitive fields to be logged:\n%s", logged)
+	}
+}
+
+func TestRedactingLoggingTransportForm(t *testing.T) {
+	transport := newRedactingLoggingTransport(http.DefaultTransport, nil, nil)
+
+	got := transport.redactBody("application/x-www-form-urlencoded", []byte("grant_type=client_credentials&client_secret=secret"))
+	if got != "client_secret=REDACTED&grant_type=client_credentials" {
+		t.Errorf("unexpected redacted form %q", got)
+	}
+}
+
+func TestRedactingLoggingTransportHeaders(t *testing.T) {
+	t.Setenv("TF_LOG", "DEBUG")
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Gateway-Key") != "gateway-secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &headerTransport{
+			base:    newRedactingLoggingTransport(http.DefaultTransport, nil, []string{"x-gateway-key"}),
+			headers: map[string]string{"x-gateway-key": "gateway-secret", "X-Team": "data"},
+		},
+	}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected the header to be sent, got %s", resp.Status)
+	}
+
+	logged := buf.String()
+	if strings.Contains(logged, "gateway-secret") {
+		t.Errorf("expected the header value to be redacted from log:\n%s", logged)
+	}
+	if !strings.Contains(logged, "X-Team: data") {
+		t.Errorf("expected the other headers to be logged:\n%s", logged)
+	}
+}
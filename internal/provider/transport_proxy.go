@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// expandProxyFunc returns the proxy selection for the configured proxy_url and
+// no_proxy, falling back to the standard proxy environment variables for
+// whichever is not set.
+func expandProxyFunc(d *schema.ResourceData) func(*http.Request) (*url.URL, error) {
+	proxyURL, hasProxyURL := d.GetOk("proxy_url")
+	noProxy, hasNoProxy := d.GetOk("no_proxy")
+	if !hasProxyURL && !hasNoProxy {
+		return http.ProxyFromEnvironment
+	}
+
+	config := httpproxy.FromEnvironment()
+	if hasProxyURL {
+		config.HTTPProxy = proxyURL.(string)
+		config.HTTPSProxy = proxyURL.(string)
+	}
+	if hasNoProxy {
+		config.NoProxy = noProxy.(string)
+	}
+
+	proxyFunc := config.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestExpandProxyFunc(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, AirflowProvider().Schema, map[string]interface{}{
+		"base_endpoint": "https://airflow.example.com",
+		"proxy_url":     "http://proxy.example.com:3128",
+		"no_proxy":      "internal.example.com,.corp.example.com",
+	})
+	proxyFunc := expandProxyFunc(d)
+
+	cases := map[string]string{
+		"https://airflow.example.com/api/v1/pools":  "http://proxy.example.com:3128",
+		"https://internal.example.com/api/v1/pools": "",
+		"https://airflow.corp.example.com/api/v2":   "",
+	}
+
+	for target, expected := range cases {
+		req, _ := http.NewRequest(http.MethodGet, target, nil)
+
+		proxy, err := proxyFunc(req)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %s", target, err)
+		}
+
+		got := ""
+		if proxy != nil {
+			got = proxy.String()
+		}
+		if got != expected {
+			t.Errorf("expected proxy %q for %s, got %q", expected, target, got)
+		}
+	}
+}
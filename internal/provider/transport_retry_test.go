@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	cases := []struct {
+		name             string
+		method           string
+		failures         []int
+		expectedAttempts int
+		expectedStatus   int
+	}{
+		{name: "recovers", method: http.MethodGet, failures: []int{503, 502}, expectedAttempts: 3, expectedStatus: 200},
+		{name: "throttled post", method: http.MethodPost, failures: []int{429}, expectedAttempts: 2, expectedStatus: 200},
+		{name: "gateway timeout post", method: http.MethodPost, failures: []int{504}, expectedAttempts: 1, expectedStatus: 504},
+		{name: "client error", method: http.MethodGet, failures: []int{400}, expectedAttempts: 1, expectedStatus: 400},
+		{name: "gives up", method: http.MethodGet, failures: []int{503, 503, 503, 503}, expectedAttempts: 3, expectedStatus: 503},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if r.Method == http.MethodPost && string(body) != `{"key":"foo"}` {
+					t.Errorf("unexpected body on attempt %d: %q", attempts, body)
+				}
+				if attempts < len(tc.failures) {
+					w.WriteHeader(tc.failures[attempts])
+				}
+				attempts++
+			}))
+			defer server.Close()
+
+			client := &http.Client{
+				Transport: &retryTransport{
+					base:       http.DefaultTransport,
+					maxRetries: 2,
+					minWait:    time.Millisecond,
+					maxWait:    5 * time.Millisecond,
+				},
+			}
+
+			req, _ := http.NewRequest(tc.method, server.URL, strings.NewReader(`{"key":"foo"}`))
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp.Body.Close()
+
+			if attempts != tc.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", tc.expectedAttempts, attempts)
+			}
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+
+	if _, ok := retryAfter(resp); ok {
+		t.Error("expected no wait without Retry-After header")
+	}
+
+	resp.Header.Set("Retry-After", "3")
+	if wait, ok := retryAfter(resp); !ok || wait != 3*time.Second {
+		t.Errorf("expected 3s, got %s", wait)
+	}
+
+	resp.Header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	if wait, ok := retryAfter(resp); !ok || wait <= 50*time.Second || wait > time.Minute {
+		t.Errorf("expected about a minute, got %s", wait)
+	}
+}
+
+func TestRetryAfterCappedAtMaxWait(t *testing.T) {
+	transport := &retryTransport{minWait: time.Second, maxWait: 30 * time.Second}
+	resp := &http.Response{Header: http.Header{}}
+
+	resp.Header.Set("Retry-After", "3600")
+	if wait := transport.backoff(0, resp); wait != 30*time.Second {
+		t.Errorf("expected the wait to be capped at 30s, got %s", wait)
+	}
+
+	resp.Header.Set("Retry-After", "3")
+	if wait := transport.backoff(0, resp); wait != 3*time.Second {
+		t.Errorf("expected 3s, got %s", wait)
+	}
+}
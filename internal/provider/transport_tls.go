@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func expandTLSConfig(d *schema.ResourceData) (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName:         d.Get("tls_server_name").(string),
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool), //nolint:gosec
+	}
+
+	var caPEM []byte
+	if v, ok := d.GetOk("ca_cert_file"); ok {
+		b, err := os.ReadFile(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca_cert_file: %s", err)
+		}
+		caPEM = b
+	} else if v, ok := d.GetOk("ca_cert_pem"); ok {
+		caPEM = []byte(v.(string))
+	}
+
+	if caPEM != nil {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no valid PEM certificates found in CA bundle")
+		}
+		config.RootCAs = pool
+	}
+
+	if v, ok := d.GetOk("client_cert"); ok {
+		certPEM, err := readPEM(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client_cert: %s", err)
+		}
+		keyPEM, err := readPEM(d.Get("client_key").(string))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client_key: %s", err)
+		}
+
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %s", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// externalTLSConfig returns the TLS config for requests to identity
+// providers, which often share the internal PKI of Airflow. The CA bundle,
+// client certificate and insecure_skip_verify apply to them, the server name
+// meant for Airflow does not.
+func externalTLSConfig(config *tls.Config) *tls.Config {
+	external := config.Clone()
+	external.ServerName = ""
+
+	return external
+}
+
+// readPEM returns v itself when it holds PEM-encoded content, otherwise v is
+// treated as the path of a file to read it from.
+func readPEM(v string) ([]byte, error) {
+	if strings.Contains(v, "-----BEGIN") {
+		return []byte(v), nil
+	}
+
+	return os.ReadFile(v)
+}
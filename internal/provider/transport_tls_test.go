@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestExpandTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	cases := []struct {
+		name      string
+		raw       map[string]interface{}
+		expectErr bool
+	}{
+		{name: "untrusted", raw: map[string]interface{}{}, expectErr: true},
+		{name: "ca_cert_pem", raw: map[string]interface{}{"ca_cert_pem": caPEM}},
+		{name: "insecure_skip_verify", raw: map[string]interface{}{"insecure_skip_verify": true}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.raw["base_endpoint"] = server.URL
+			d := schema.TestResourceDataRaw(t, AirflowProvider().Schema, tc.raw)
+
+			config, err := expandTLSConfig(d)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			transport := http.DefaultTransport.(*http.Transport).Clone()
+			transport.TLSClientConfig = config
+
+			resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected certificate verification to fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestExternalTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	d := schema.TestResourceDataRaw(t, AirflowProvider().Schema, map[string]interface{}{
+		"base_endpoint":   "https://airflow.internal",
+		"ca_cert_pem":     caPEM,
+		"tls_server_name": "airflow.internal",
+	})
+
+	config, err := expandTLSConfig(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = externalTLSConfig(config)
+
+	// The identity provider is verified with the CA bundle, under its own name.
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+
+	if config.ServerName != "airflow.internal" {
+		t.Errorf("expected the TLS config of Airflow to be left unchanged, got server name %q", config.ServerName)
+	}
+}
+
+func TestExpandTLSConfig_invalidCA(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, AirflowProvider().Schema, map[string]interface{}{
+		"base_endpoint": "https://localhost",
+		"ca_cert_pem":   "not a certificate",
+	})
+
+	if _, err := expandTLSConfig(d); err == nil {
+		t.Fatal("expected an error for an invalid CA bundle")
+	}
+}
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"log"
 
 	"github.com/drfaust92/terraform-provider-airflow/internal/provider"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 )
 
 func main() {
@@ -14,11 +16,25 @@ func main() {
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return provider.AirflowProvider()
-		},
-		ProviderAddr: "registry.terraform.io/drfaust92/airflow",
-		Debug:        debug,
-	})
+	muxServer, err := provider.NewMuxServer(context.Background(), provider.AirflowProvider())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serveOpts []tf5server.ServeOpt
+	if debug {
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
+	}
+
+	err = tf5server.Serve(
+		"registry.terraform.io/drfaust92/airflow",
+		func() tfprotov5.ProviderServer { return muxServer },
+		serveOpts...,
+	)
+	if err := provider.ShutdownTracing(); err != nil {
+		log.Printf("[WARN] failed to export the remaining OpenTelemetry spans: %s", err)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
 }